@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"strings"
-
-	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -34,56 +31,15 @@ func init() {
 }
 
 func runBezirke(cmd *cobra.Command, args []string) error {
-	search, _ := cmd.Flags().GetString("search")
-	title, _ := cmd.Flags().GetString("title")
-	state, _ := cmd.Flags().GetString("state")
-	authority, _ := cmd.Flags().GetString("authority")
-	number, _ := cmd.Flags().GetString("number")
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	since, _ := cmd.Flags().GetString("since")
-
-	// At least one required.
-	if search == "" && title == "" && state == "" && authority == "" && number == "" {
-		return errValidation("Fehler: mindestens --search, --title, --state, --authority oder --number erforderlich")
-	}
-
-	params := api.NewParams()
-	params.Set("Applikation", "Bvb")
-
-	if search != "" {
-		params.Set("Suchworte", search)
-	}
-	if title != "" {
-		params.Set("Titel", title)
-	}
-	if state != "" {
-		// Bezirke uses display names with Umlauts.
-		value, ok := constants.BezirkeStates[strings.ToLower(state)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --state Wert %q\nGültig: wien, niederoesterreich, oberoesterreich, salzburg, tirol, vorarlberg, kaernten, steiermark, burgenland", state)
-		}
-		params.Set("Bundesland", value)
-	}
-	if authority != "" {
-		params.Set("Bezirksverwaltungsbehoerde", authority)
-	}
-	if number != "" {
-		params.Set("Kundmachungsnummer", number)
-	}
-	if from != "" {
-		params.Set("Kundmachungsdatum.Von", from)
-	}
-	if to != "" {
-		params.Set("Kundmachungsdatum.Bis", to)
-	}
-	if since != "" {
-		value, ok := constants.ImRisSeit[strings.ToLower(since)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --since Wert %q", since)
-		}
-		params.Set("ImRisSeit", value)
-	}
-
-	return executeSearch(cmd, "Bezirke", "Suche in Bezirksverwaltung...", params)
+	var q query.BezirkeQuery
+	q.Search, _ = cmd.Flags().GetString("search")
+	q.Title, _ = cmd.Flags().GetString("title")
+	q.State, _ = cmd.Flags().GetString("state")
+	q.Authority, _ = cmd.Flags().GetString("authority")
+	q.Number, _ = cmd.Flags().GetString("number")
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.Since, _ = cmd.Flags().GetString("since")
+
+	return executeQuery(cmd, q, "Suche in Bezirksverwaltung...")
 }
@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"strings"
-
-	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -32,45 +29,13 @@ func init() {
 }
 
 func runBgbl(cmd *cobra.Command, args []string) error {
-	number, _ := cmd.Flags().GetString("number")
-	year, _ := cmd.Flags().GetString("year")
-	search, _ := cmd.Flags().GetString("search")
-	title, _ := cmd.Flags().GetString("title")
-	part, _ := cmd.Flags().GetString("part")
-	app, _ := cmd.Flags().GetString("app")
-
-	// At least one of number/year/search/title required.
-	if number == "" && year == "" && search == "" && title == "" {
-		return errValidation("Fehler: mindestens --number, --year, --search oder --title erforderlich")
-	}
-
-	appValue, ok := constants.BgblApps[strings.ToLower(app)]
-	if !ok {
-		return errValidation("Fehler: ungültiger --app Wert %q (gültig: bgblauth, bgblpdf, bgblalt)", app)
-	}
-
-	params := api.NewParams()
-	params.Set("Applikation", appValue)
-
-	if number != "" {
-		params.Set("Bgblnummer", number)
-	}
-	if year != "" {
-		params.Set("Jahrgang", year)
-	}
-	if search != "" {
-		params.Set("Suchworte", search)
-	}
-	if title != "" {
-		params.Set("Titel", title)
-	}
-	if part != "" {
-		teilValue, ok := constants.BgblTeile[part]
-		if !ok {
-			return errValidation("Fehler: ungültiger --part Wert %q (gültig: 1, 2, 3)", part)
-		}
-		params.Set("Teil", teilValue)
-	}
-
-	return executeSearch(cmd, "Bundesrecht", "Suche in Bundesgesetzblättern...", params)
+	var q query.BgblQuery
+	q.Number, _ = cmd.Flags().GetString("number")
+	q.Year, _ = cmd.Flags().GetString("year")
+	q.Search, _ = cmd.Flags().GetString("search")
+	q.Title, _ = cmd.Flags().GetString("title")
+	q.Part, _ = cmd.Flags().GetString("part")
+	q.App, _ = cmd.Flags().GetString("app")
+
+	return executeQuery(cmd, q, "Suche in Bundesgesetzblättern...")
 }
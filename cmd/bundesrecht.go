@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"strings"
-
-	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +14,9 @@ Beispiele:
   risgo bundesrecht --search "Mietrecht"
   risgo bundesrecht --title "ABGB" --paragraph 1295
   risgo bundesrecht --search "Schadenersatz" --app begut
-  risgo bundesrecht --search "Mietrecht" --date 2024-01-15 --json`,
+  risgo bundesrecht --search "Mietrecht" --date 2024-01-15 --json
+  risgo bundesrecht --law-number 10001622 --paragraph 1295
+  risgo bundesrecht --index "20/01" --type BG`,
 	RunE: runBundesrecht,
 }
 
@@ -28,45 +27,25 @@ func init() {
 	f.String("paragraph", "", "Paragraphennummer (z.B. \"1295\")")
 	f.String("app", "brkons", "Applikation: brkons, begut, bgblauth, erv")
 	f.String("date", "", "Fassungsdatum (JJJJ-MM-TT)")
+	f.String("law-number", "", "Gesetzesnummer (z.B. \"10001622\" für das ABGB)")
+	f.String("type", "", "Normtyp (z.B. BG, BVG, V; nur brkons)")
+	f.String("index", "", "Sachgebiet-Index (z.B. \"20/01\"; nur brkons)")
+	f.String("keywords", "", "Suche in Schlagworten")
 
 	rootCmd.AddCommand(bundesrechtCmd)
 }
 
 func runBundesrecht(cmd *cobra.Command, args []string) error {
-	search, _ := cmd.Flags().GetString("search")
-	title, _ := cmd.Flags().GetString("title")
-	paragraph, _ := cmd.Flags().GetString("paragraph")
-	app, _ := cmd.Flags().GetString("app")
-	date, _ := cmd.Flags().GetString("date")
-
-	// At least one of search/title/paragraph required.
-	if search == "" && title == "" && paragraph == "" {
-		return errValidation("Fehler: mindestens --search, --title oder --paragraph erforderlich")
-	}
-
-	// Resolve application value.
-	appValue, ok := constants.BundesrechtApps[strings.ToLower(app)]
-	if !ok {
-		return errValidation("Fehler: ungültiger --app Wert %q (gültig: brkons, begut, bgblauth, erv)", app)
-	}
-
-	params := api.NewParams()
-	params.Set("Applikation", appValue)
-
-	if search != "" {
-		params.Set("Suchworte", search)
-	}
-	if title != "" {
-		params.Set("Titel", title)
-	}
-	if paragraph != "" {
-		params.Set("Abschnitt.Von", paragraph)
-		params.Set("Abschnitt.Bis", paragraph)
-		params.Set("Abschnitt.Typ", "Paragraph")
-	}
-	if date != "" {
-		params.Set("FassungVom", date)
-	}
-
-	return executeSearch(cmd, "Bundesrecht", "Suche in Bundesrecht...", params)
+	var q query.BundesrechtQuery
+	q.Search, _ = cmd.Flags().GetString("search")
+	q.Title, _ = cmd.Flags().GetString("title")
+	q.Paragraph, _ = cmd.Flags().GetString("paragraph")
+	q.App, _ = cmd.Flags().GetString("app")
+	q.Date, _ = cmd.Flags().GetString("date")
+	q.LawNumber, _ = cmd.Flags().GetString("law-number")
+	q.Type, _ = cmd.Flags().GetString("type")
+	q.Index, _ = cmd.Flags().GetString("index")
+	q.Keywords, _ = cmd.Flags().GetString("keywords")
+
+	return executeQuery(cmd, q, "Suche in Bundesrecht...")
 }
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/philrox/risgo/pkg/ristest"
+	"github.com/spf13/cobra"
+)
+
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Werkzeuge für Entwicklung und Tests",
+	Long:  "Werkzeuge für die Entwicklung von risgo und für Integrationstests.",
+}
+
+var mockServerCmd = &cobra.Command{
+	Use:   "mock-server",
+	Short: "Lokale Nachbildung der RIS OGD API starten",
+	Long: `Lokale Nachbildung der RIS OGD API v2.6 starten.
+
+Bedient alle Such-Endpunkte (Bundesrecht, Landesrecht, Judikatur, Bezirke,
+Gemeinden, Sonstige, History) sowie Dokument-HTML aus einem Fixture-Verzeichnis
+und berücksichtigt Seitennummer und DokumenteProSeite. Ohne --fixtures werden
+die mitgelieferten Beispieldaten verwendet.
+
+Fixture-Verzeichnis:
+  <Endpunkt>.json        JSON-Array von OgdDocumentReference-Objekten
+  documents/<ID>.html    Dokumentinhalt
+  {{base}} in URLs wird durch die Adresse des Servers ersetzt.
+
+Beispiele:
+  risgo dev mock-server
+  RIS_BASE_URL=http://127.0.0.1:8080/ risgo bundesrecht --search "ABGB"
+  risgo dev mock-server --addr :9000 --latency 500ms --fail-rate 0.2
+  risgo dev mock-server --rate-limit-every 3`,
+	Args: cobra.NoArgs,
+	RunE: runMockServer,
+}
+
+func init() {
+	f := mockServerCmd.Flags()
+	f.String("addr", "127.0.0.1:8080", "Adresse, auf der der Server lauscht")
+	f.String("fixtures", "", "Fixture-Verzeichnis (Standard: mitgelieferte Beispieldaten)")
+	f.Duration("latency", 0, "Verzögerung jeder Antwort")
+	f.Int("fail-first", 0, "Die ersten N Anfragen mit --fail-status beantworten")
+	f.Float64("fail-rate", 0, "Anteil der Anfragen (0-1), die mit --fail-status beantwortet werden")
+	f.Int("fail-status", 503, "HTTP-Status für simulierte Fehler (500-599)")
+	f.Int("rate-limit-every", 0, "Jede N-te Anfrage mit 429 Too Many Requests beantworten")
+	f.Duration("retry-after", time.Second, "Retry-After bei simulierten 429-Antworten")
+
+	devCmd.AddCommand(mockServerCmd)
+	rootCmd.AddCommand(devCmd)
+}
+
+func runMockServer(cmd *cobra.Command, args []string) error {
+	addr, _ := cmd.Flags().GetString("addr")
+	fixtures, _ := cmd.Flags().GetString("fixtures")
+	latency, _ := cmd.Flags().GetDuration("latency")
+	failFirst, _ := cmd.Flags().GetInt("fail-first")
+	failRate, _ := cmd.Flags().GetFloat64("fail-rate")
+	failStatus, _ := cmd.Flags().GetInt("fail-status")
+	rateLimitEvery, _ := cmd.Flags().GetInt("rate-limit-every")
+	retryAfter, _ := cmd.Flags().GetDuration("retry-after")
+
+	if failRate < 0 || failRate > 1 {
+		return errValidation("Fehler: --fail-rate muss zwischen 0 und 1 liegen")
+	}
+	if failStatus < 500 || failStatus > 599 {
+		return errValidation("Fehler: --fail-status muss zwischen 500 und 599 liegen")
+	}
+	if latency < 0 || failFirst < 0 || rateLimitEvery < 0 {
+		return errValidation("Fehler: --latency, --fail-first und --rate-limit-every dürfen nicht negativ sein")
+	}
+
+	opts := ristest.Options{
+		Latency:        latency,
+		FailFirst:      failFirst,
+		FailRate:       failRate,
+		FailStatus:     failStatus,
+		RateLimitEvery: rateLimitEvery,
+		RetryAfter:     retryAfter,
+	}
+	if fixtures != "" {
+		if _, err := os.Stat(fixtures); err != nil {
+			return errValidation("Fehler: Fixture-Verzeichnis nicht lesbar: %v", err)
+		}
+		opts.Fixtures = os.DirFS(fixtures)
+	}
+
+	handler, err := ristest.NewHandler(opts)
+	if err != nil {
+		return fmt.Errorf("Fixtures konnten nicht geladen werden: %w", err)
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("Server konnte nicht gestartet werden: %w", err)
+	}
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	baseURL := "http://" + ln.Addr().String() + "/"
+	fmt.Fprintf(os.Stderr, "RIS-Mock-Server läuft auf %s (Beenden mit Ctrl-C)\n", baseURL)
+	fmt.Fprintf(os.Stderr, "  export RIS_BASE_URL=%s\n", baseURL)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("Server beendet: %w", err)
+	case <-commandContext(cmd).Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Mock-Server beendet (%d Anfragen).\n", handler.Requests())
+	}
+	return nil
+}
@@ -1,41 +1,92 @@
 package cmd
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
-	"net/url"
+	"io"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"syscall"
 
+	"github.com/fatih/color"
 	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
 	"github.com/philrox/risgo/internal/format"
 	"github.com/philrox/risgo/internal/model"
-	"github.com/philrox/risgo/internal/parser"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/philrox/risgo/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var dokumentCmd = &cobra.Command{
-	Use:   "dokument [document-number]",
+	Use:   "dokument [document-number...]",
 	Short: "Volltext eines Dokuments abrufen",
-	Long: `Volltext eines Rechtsdokuments abrufen.
+	Long: `Volltext eines oder mehrerer Rechtsdokumente abrufen.
+
+Mehrere Dokumentnummern werden parallel abgerufen (--concurrency, Standard 4)
+und in der angegebenen Reihenfolge ausgegeben. "-" liest Dokumentnummern
+zeilenweise von stdin. Fehler bei einzelnen Dokumenten brechen den Abruf
+nicht ab, sondern werden an ihrer Stelle gemeldet.
 
 Beispiele:
   risgo dokument NOR40052761
   risgo dokument NOR40052761 --json
+  risgo dokument NOR40052761 --format pdf --output NOR40052761.pdf
+  risgo dokument NOR40052761 NOR40052762 NOR40052763
+  risgo bundesrecht --title ABGB --json | jq -r '.documents[].dokumentnummer' | risgo dokument - --ndjson
   risgo dokument --url "https://ris.bka.gv.at/Dokumente/Bundesnormen/NOR40052761/NOR40052761.html"`,
-	Args: cobra.MaximumNArgs(1),
 	RunE: runDokument,
 }
 
 func init() {
 	f := dokumentCmd.Flags()
 	f.String("url", "", "Direkte URL zum Dokumentinhalt")
+	f.String("format", "text", "Ausgabeformat: text (aus HTML konvertiert) oder Originalfassung als html, xml, pdf, rtf")
+	f.StringP("output", "o", "", "Dokument in `DATEI` schreiben statt auf stdout")
+	f.Bool("ndjson", false, "Mehrere Dokumente als JSON-Objekt pro Zeile ausgeben statt als JSON-Array (impliziert --json)")
 
 	rootCmd.AddCommand(dokumentCmd)
 }
 
+// documentFormat describes a value of --format: the rendition to download
+// and the media types its response may have.
+type documentFormat struct {
+	name       string
+	dataType   string   // data type of the rendition in ContentURLs
+	mediaTypes []string // accepted Content-Types (nil = not checked)
+	binary     bool     // not fit for a terminal
+}
+
+// documentFormats lists the values of --format. "text" converts the HTML
+// rendition; all others are written byte-for-byte.
+var documentFormats = []documentFormat{
+	{name: "text", dataType: "html"},
+	{name: "html", dataType: "html", mediaTypes: []string{"text/html", "application/xhtml+xml"}},
+	{name: "xml", dataType: "xml", mediaTypes: []string{"application/xml", "text/xml"}},
+	{name: "pdf", dataType: "pdf", mediaTypes: []string{"application/pdf"}, binary: true},
+	{name: "rtf", dataType: "rtf", mediaTypes: []string{"application/rtf", "text/rtf", "application/msword"}, binary: true},
+}
+
+// parseDocumentFormat looks up a --format value.
+func parseDocumentFormat(name string) (documentFormat, error) {
+	names := make([]string, len(documentFormats))
+	for i, f := range documentFormats {
+		if strings.EqualFold(f.name, name) {
+			return f, nil
+		}
+		names[i] = f.name
+	}
+	return documentFormat{}, errValidation("Fehler: ungültiger Wert für --format: %q (erlaubt: %s)", name, strings.Join(names, ", "))
+}
+
+// raw reports whether the rendition is written unconverted.
+func (f documentFormat) raw() bool { return f.name != "text" }
+
 var docNumberRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]+$`)
 
 func validateDocNumber(nr string) error {
@@ -48,40 +99,80 @@ func validateDocNumber(nr string) error {
 	return nil
 }
 
-func validateURL(rawURL string) error {
-	u, err := url.Parse(rawURL)
+func runDokument(cmd *cobra.Command, args []string) error {
+	docURL, _ := cmd.Flags().GetString("url")
+	formatName, _ := cmd.Flags().GetString("format")
+	output, _ := cmd.Flags().GetString("output")
+	ndjson, _ := cmd.Flags().GetBool("ndjson")
+	if ndjson {
+		jsonOutput = true
+	}
+
+	if len(args) == 0 && docURL == "" {
+		return errValidation("Fehler: Dokumentnummer oder --url erforderlich")
+	}
+	batch := len(args) > 1 || slices.Contains(args, "-")
+	if batch && docURL != "" {
+		return errValidation("Fehler: --url erlaubt höchstens eine Dokumentnummer")
+	}
+	docFormat, err := parseDocumentFormat(formatName)
 	if err != nil {
-		return fmt.Errorf("Ungültige URL: %w", err)
+		return err
 	}
-	if u.Scheme != "https" {
-		return fmt.Errorf("Nur HTTPS-URLs erlaubt, erhalten: %q", u.Scheme)
+	if outputDir != "" {
+		return runDokumentToDir(cmd, docFormat, args, docURL, output)
 	}
-	host := strings.ToLower(u.Hostname())
-	if !api.AllowedHosts[host] {
-		return fmt.Errorf("Host %q nicht erlaubt", host)
+	if docFormat.raw() && useJSON(cmd) {
+		return errValidation("Fehler: --json ist nur mit --format text möglich")
+	}
+	if docFormat.raw() && batch {
+		return errValidation("Fehler: mehrere Dokumente sind nur mit --format text möglich")
+	}
+	if docFormat.binary && output == "" && IsTTY() {
+		return errValidation("Fehler: --format %s liefert Binärdaten, bitte mit --output DATEI speichern oder umleiten", docFormat.name)
+	}
+	out := documentOutput{format: docFormat, path: output}
+
+	if batch {
+		numbers, err := readDocNumbers(args, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		client, err := newClient(cmd)
+		if err != nil {
+			return err
+		}
+		return runDokumentBatch(cmd, client, out, numbers, ndjson)
 	}
-	return nil
-}
 
-func runDokument(cmd *cobra.Command, args []string) error {
-	docURL, _ := cmd.Flags().GetString("url")
 	var docNumber string
 	if len(args) > 0 {
 		docNumber = args[0]
 	}
 
-	if docNumber == "" && docURL == "" {
-		return errValidation("Fehler: Dokumentnummer oder --url erforderlich")
+	client, err := newClient(cmd)
+	if err != nil {
+		return err
 	}
 
-	client := newClient(cmd)
-
 	if docURL != "" {
-		// Direct URL fetch.
-		if err := validateURL(docURL); err != nil {
-			return errValidation("Fehler: %v", err)
+		// Direct URL fetch; the client decides which hosts are allowed.
+		meta := func() (model.Document, error) { return model.Document{}, errNoDocNumber }
+		if docNumber != "" && validateDocNumber(docNumber) == nil {
+			meta = newMetadataLookup(commandContext(cmd), client, docNumber, !docFormat.raw())
 		}
-		return fetchAndOutputDocument(cmd, client, docURL, docNumber)
+		s := startSpinner(cmd, "Lade Dokument...")
+		body, err := client.OpenDocument(commandContext(cmd), docURL, docFormat.mediaTypes...)
+		stopSpinner(s)
+		var urlErr *api.URLError
+		if errors.As(err, &urlErr) {
+			return errValidation("Fehler: %v", urlErr)
+		}
+		if err != nil {
+			return fmt.Errorf("Dokument konnte nicht abgerufen werden: %w", err)
+		}
+		defer body.Close()
+		return outputDocumentContent(cmd, out, documentSource{body: body, url: docURL, meta: meta}, docNumber)
 	}
 
 	// Document number strategy.
@@ -89,74 +180,172 @@ func runDokument(cmd *cobra.Command, args []string) error {
 		return errValidation("Fehler: %v", err)
 	}
 
+	s := startSpinner(cmd, "Lade Dokument...")
+	src, err := openDocument(commandContext(cmd), client, docFormat, docNumber)
+	stopSpinner(s)
+	if err != nil {
+		return err
+	}
+	if src.body == nil {
+		// No content URL found; output metadata only.
+		doc, err := src.meta()
+		if err != nil {
+			return err
+		}
+		return out.write(cmd, func(w io.Writer) error {
+			if useJSON(cmd) {
+				return format.JSONDocument(w, doc, "")
+			}
+			return format.TextDocument(w, doc, "")
+		})
+	}
+	defer src.body.Close()
+	return outputDocumentContent(cmd, out, src, docNumber)
+}
+
+// runDokumentToDir saves the documents given as arguments to --output-dir.
+func runDokumentToDir(cmd *cobra.Command, docFormat documentFormat, args []string, docURL, output string) error {
+	if docURL != "" || output != "" {
+		return errValidation("Fehler: --output-dir ist nicht mit --url oder --output kombinierbar")
+	}
+	numbers, err := readDocNumbers(args, cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+	saver, err := newFileSaver(docFormat)
+	if err != nil {
+		return err
+	}
+	client, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+	jobs := make([]saveJob, len(numbers))
+	for i, nr := range numbers {
+		jobs[i] = saveJob{number: nr}
+	}
+	return saveDocuments(cmd, client, saver, jobs)
+}
+
+// documentSource is an opened document: its content stream and the URL it
+// was fetched from, plus the lookup of its metadata.
+type documentSource struct {
+	body io.ReadCloser // nil if the document has no content, only metadata
+	url  string
+	meta func() (model.Document, error)
+}
+
+// openDocument opens the requested rendition of a document: first via the
+// URL derived from its number's prefix (only against the public RIS API, see
+// api.Client.PublicAPI), then via the content URLs from the search API. Text and JSON output show the document's metadata, which only
+// the search API provides, so for them it is looked up while the content is
+// fetched; raw downloads need it only if the direct URL fails.
+func openDocument(ctx context.Context, client *api.Client, docFormat documentFormat, docNumber string) (documentSource, error) {
+	meta := newMetadataLookup(ctx, client, docNumber, !docFormat.raw())
+
 	// Step 1: Try direct URL from prefix routing table.
-	directURL := model.DirectURLFromPrefix(docNumber)
+	var directURL string
+	if client.PublicAPI() {
+		directURL = model.DirectURLForType(docNumber, docFormat.dataType)
+	}
 	if directURL != "" {
-		s := startSpinner(cmd, "Lade Dokument...")
-		htmlContent, err := client.FetchDocument(directURL)
-		stopSpinner(s)
+		body, err := client.OpenDocument(ctx, directURL, docFormat.mediaTypes...)
 		if err == nil {
-			return outputDocumentContent(cmd, docNumber, directURL, htmlContent)
+			return documentSource{body: body, url: directURL, meta: meta}, nil
+		}
+		if errors.Is(err, context.Canceled) {
+			return documentSource{}, err
 		}
 		// Direct URL failed, fall through to search.
-		if isVerbose() {
-			fmt.Fprintf(os.Stderr, "Direkte URL fehlgeschlagen (%v), versuche Suche als Fallback...\n", err)
+		if isVerbose() || traceHTTP {
+			fmt.Fprintf(os.Stderr, "Direkte URL für %s fehlgeschlagen (%v), versuche Suche als Fallback...\n", docNumber, err)
 		}
 	}
 
-	// Step 2: Fallback to search API.
-	endpoint, applikation := model.SearchFallback(docNumber)
-	params := api.NewParams()
-	params.Set("Applikation", applikation)
-	params.Set("Dokumentnummer", docNumber)
-	params.Set("DokumenteProSeite", constants.PageSizes[10])
-
-	s2 := startSpinner(cmd, "Suche Dokument-URL...")
-	body, err := client.Search(endpoint, params)
-	stopSpinner(s2)
+	// Step 2: Fallback to the content URLs from the search API.
+	doc, err := meta()
 	if err != nil {
-		return fmt.Errorf("Such-API-Anfrage fehlgeschlagen: %w", err)
+		return documentSource{}, err
+	}
+
+	contentURL := doc.ContentURLs.ForType(docFormat.dataType)
+	if contentURL == "" && docFormat.dataType == "html" {
+		contentURL = doc.DokumentURL
 	}
 
-	result, err := parser.ParseSearchResponse(body)
+	if traceHTTP {
+		fmt.Fprintf(os.Stderr, "Dokument-URL aus Suche: %s\n", cmp.Or(contentURL, "(keine, nur Metadaten)"))
+	}
+
+	if contentURL == "" {
+		if docFormat.raw() {
+			return documentSource{}, errNotFound("Fehler: Dokument %q ist nicht als %s verfügbar", docNumber, strings.ToUpper(docFormat.name))
+		}
+		return documentSource{meta: meta}, nil
+	}
+
+	body, err := client.OpenDocument(ctx, contentURL, docFormat.mediaTypes...)
 	if err != nil {
-		return fmt.Errorf("Suchantwort konnte nicht verarbeitet werden: %w", err)
+		return documentSource{}, fmt.Errorf("Dokument konnte nicht abgerufen werden: %w", err)
 	}
+	return documentSource{body: body, url: contentURL, meta: meta}, nil
+}
 
-	if len(result.Documents) == 0 {
-		return errValidation("Fehler: Dokument %q nicht gefunden", docNumber)
+// errNoDocNumber is returned by the metadata lookup of "dokument --url"
+// without a document number.
+var errNoDocNumber = errors.New("keine Dokumentnummer angegeben")
+
+// newMetadataLookup returns a function that looks up the document's metadata
+// through the search API once and returns the result on every call. If start
+// is set, the lookup begins immediately in the background.
+func newMetadataLookup(ctx context.Context, client *api.Client, docNumber string, start bool) func() (model.Document, error) {
+	lookup := sync.OnceValues(func() (model.Document, error) {
+		return lookupDocument(ctx, client, docNumber)
+	})
+	if start {
+		go lookup()
 	}
+	return lookup
+}
 
-	// Find HTML content URL from search result.
-	doc := result.Documents[0]
-	htmlURL := ""
-	if doc.ContentURLs.HTML != "" {
-		htmlURL = doc.ContentURLs.HTML
-	} else if doc.DokumentURL != "" {
-		htmlURL = doc.DokumentURL
+// lookupDocument finds a document by number through the search endpoint
+// derived from its prefix (see model.SearchFallback).
+func lookupDocument(ctx context.Context, client *api.Client, docNumber string) (model.Document, error) {
+	lookup := query.DocumentQuery{Number: docNumber}
+	params, err := lookup.Params()
+	if err != nil {
+		return model.Document{}, queryError(err)
 	}
 
-	if htmlURL == "" {
-		// No content URL found; output metadata only.
-		if useJSON(cmd) {
-			return format.JSONDocument(os.Stdout, doc, "")
-		}
-		w, cleanup := ui.NewPagerWriter(!usePager(cmd))
-		defer cleanup()
-		return format.TextDocument(w, doc, "")
+	result, err := searchPage(ctx, client, lookup.Endpoint(), params)
+	if err != nil {
+		return model.Document{}, err
 	}
 
-	return fetchAndOutputDocument(cmd, client, htmlURL, docNumber)
+	if len(result.Documents) == 0 {
+		return model.Document{}, errNotFound("Fehler: Dokument %q nicht gefunden", docNumber)
+	}
+	return result.Documents[0], nil
 }
 
-func fetchAndOutputDocument(cmd *cobra.Command, client *api.Client, docURL, docNumber string) error {
-	s := startSpinner(cmd, "Lade Dokument...")
-	htmlContent, err := client.FetchDocument(docURL)
-	stopSpinner(s)
+// documentMetadata waits for the metadata lookup of a document whose content
+// was fetched from docURL. If the lookup fails, the document is shown with
+// its number and URL only.
+func documentMetadata(meta func() (model.Document, error), docNumber, docURL string) (model.Document, error) {
+	doc, err := meta()
+	if errors.Is(err, context.Canceled) {
+		return doc, err
+	}
 	if err != nil {
-		return fmt.Errorf("Dokument konnte nicht abgerufen werden: %w", err)
+		if isVerbose() && !errors.Is(err, errNoDocNumber) {
+			fmt.Fprintf(os.Stderr, "Metadaten nicht verfügbar (%v)\n", err)
+		}
+		doc = model.Document{Dokumentnummer: docNumber}
+	}
+	if doc.DokumentURL == "" {
+		doc.DokumentURL = docURL
 	}
-	return outputDocumentContent(cmd, docNumber, docURL, htmlContent)
+	return doc, nil
 }
 
 // usePager returns true when pager should be used for document output.
@@ -164,20 +353,106 @@ func usePager(cmd *cobra.Command) bool {
 	return !useJSON(cmd) && !plainOutput && !quiet && !noPager
 }
 
-func outputDocumentContent(cmd *cobra.Command, docNumber, docURL, htmlContent string) error {
-	textContent := format.HTMLToText(htmlContent)
+// documentOutput is where and in which format dokument writes a document.
+type documentOutput struct {
+	format documentFormat
+	path   string // --output, "" for stdout
+}
 
-	if useJSON(cmd) {
-		doc := model.Document{
-			Dokumentnummer: docNumber,
-			DokumentURL:    docURL,
-		}
-		return format.JSONDocument(os.Stdout, doc, textContent)
+// write calls fn with the destination: the --output file, the pager for
+// text on a terminal, or stdout.
+func (o documentOutput) write(cmd *cobra.Command, fn func(w io.Writer) error) error {
+	if o.path != "" {
+		color.NoColor = true // files never get ANSI colors
+		return writeFileAtomic(o.path, fn)
+	}
+	if o.format.raw() {
+		return ignoreEPIPE(fn(os.Stdout))
 	}
-
 	w, cleanup := ui.NewPagerWriter(!usePager(cmd))
 	defer cleanup()
+	return ignoreEPIPE(fn(w))
+}
+
+// ignoreEPIPE treats a closed pager or pipe as success: the reader has seen
+// all it wanted.
+func ignoreEPIPE(err error) error {
+	if errors.Is(err, syscall.EPIPE) {
+		return nil
+	}
+	return err
+}
 
-	fmt.Fprintln(w, textContent)
+// writeFileAtomic writes the output of fn to a temporary file next to path
+// and renames it into place, so a failed download never leaves a truncated
+// file behind.
+func writeFileAtomic(path string, fn func(w io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("Fehler: Ausgabedatei konnte nicht angelegt werden: %w", err)
+	}
+	defer os.Remove(f.Name()) // no-op after the rename
+	if err := fn(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return fmt.Errorf("Fehler: Ausgabedatei konnte nicht geschrieben werden: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("Fehler: Ausgabedatei konnte nicht geschrieben werden: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("Fehler: Ausgabedatei konnte nicht geschrieben werden: %w", err)
+	}
 	return nil
 }
+
+// outputDocumentContent writes the document read from body. Raw formats are
+// copied unchanged; for text the HTML is converted as the document arrives
+// and shown below the document's metadata.
+func outputDocumentContent(cmd *cobra.Command, out documentOutput, src documentSource, docNumber string) error {
+	if out.format.raw() {
+		var n int64
+		err := out.write(cmd, func(w io.Writer) error {
+			var err error
+			n, err = io.Copy(w, src.body)
+			if err != nil {
+				return fmt.Errorf("Dokument konnte nicht gelesen werden: %w", err)
+			}
+			return nil
+		})
+		if err == nil && out.path != "" && !quiet {
+			fmt.Fprintf(os.Stderr, "%s gespeichert (%s, %d Bytes)\n", out.path, strings.ToUpper(out.format.name), n)
+		}
+		return err
+	}
+
+	s := startSpinner(cmd, "Lade Metadaten...")
+	doc, err := documentMetadata(src.meta, docNumber, src.url)
+	stopSpinner(s)
+	if err != nil {
+		return err
+	}
+
+	if useJSON(cmd) {
+		var text strings.Builder
+		if err := format.WriteHTMLText(&text, src.body); err != nil {
+			return fmt.Errorf("Dokument konnte nicht gelesen werden: %w", err)
+		}
+		return out.write(cmd, func(w io.Writer) error {
+			return format.JSONDocument(w, doc, text.String())
+		})
+	}
+
+	return out.write(cmd, func(w io.Writer) error {
+		if err := format.TextDocumentHTML(w, doc, src.body); err != nil {
+			if errors.Is(err, syscall.EPIPE) {
+				return err // pager closed before the end of the document
+			}
+			return fmt.Errorf("Dokument konnte nicht gelesen werden: %w", err)
+		}
+		return nil
+	})
+}
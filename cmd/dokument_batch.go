@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/format"
+	"github.com/philrox/risgo/internal/model"
+	"github.com/spf13/cobra"
+)
+
+// defaultDocumentConcurrency is the number of documents fetched in parallel
+// by "dokument" with several document numbers unless --concurrency is given.
+const defaultDocumentConcurrency = 4
+
+// readDocNumbers expands the arguments of "dokument" into document numbers:
+// "-" stands for the newline-separated numbers read from stdin. Blank lines
+// are skipped.
+func readDocNumbers(args []string, stdin io.Reader) ([]string, error) {
+	var numbers []string
+	for _, arg := range args {
+		if arg != "-" {
+			numbers = append(numbers, arg)
+			continue
+		}
+		sc := bufio.NewScanner(stdin)
+		for sc.Scan() {
+			if nr := strings.TrimSpace(sc.Text()); nr != "" {
+				numbers = append(numbers, nr)
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("Fehler: Dokumentnummern konnten nicht von stdin gelesen werden: %w", err)
+		}
+	}
+	if len(numbers) == 0 {
+		return nil, errValidation("Fehler: keine Dokumentnummern angegeben")
+	}
+	return numbers, nil
+}
+
+// documentConcurrency returns the number of documents to fetch in parallel:
+// --concurrency if given, defaultDocumentConcurrency otherwise.
+func documentConcurrency(cmd *cobra.Command) int {
+	if cmd.Root().PersistentFlags().Changed("concurrency") {
+		return concurrency
+	}
+	return defaultDocumentConcurrency
+}
+
+// fetchOrdered calls fetch for the indexes 0..n-1 on up to workers
+// goroutines, so the caller can consume the results in order (see
+// orderedResults.next) while later ones are still being fetched. Fetching
+// runs at most 2×workers indexes ahead of the caller, which bounds the
+// results held in memory when an early one is slow. Cancelling ctx stops
+// handing out further indexes.
+func fetchOrdered[T any](ctx context.Context, n, workers int, fetch func(i int) T) *orderedResults[T] {
+	o := &orderedResults[T]{
+		results: make([]chan T, n),
+		slots:   make(chan struct{}, 2*max(workers, 1)),
+	}
+	for i := range o.results {
+		o.results[i] = make(chan T, 1)
+	}
+	next := make(chan int)
+	go func() {
+		defer close(next)
+		for i := range n {
+			select {
+			case o.slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case next <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for range min(workers, n) {
+		go func() {
+			for i := range next {
+				o.results[i] <- fetch(i)
+			}
+		}()
+	}
+	return o
+}
+
+// orderedResults are the results of fetchOrdered.
+type orderedResults[T any] struct {
+	results []chan T
+	slots   chan struct{} // one per index handed out but not yet consumed
+	pos     int
+}
+
+// next waits for the result of the next index and lets fetching advance by
+// one index. It returns ctx.Err() if ctx is cancelled first.
+func (o *orderedResults[T]) next(ctx context.Context) (T, error) {
+	select {
+	case res := <-o.results[o.pos]:
+		o.pos++
+		<-o.slots
+		return res, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// documentResult is a fetched document of a batch, or the reason it could
+// not be fetched.
+type documentResult struct {
+	doc     model.Document
+	content string
+	err     error
+}
+
+// runDokumentBatch fetches several documents with up to --concurrency
+// documents in flight and writes them in the given order. Documents that
+// fail are reported in place and do not abort the batch; the command then
+// ends with an error naming the first failure.
+func runDokumentBatch(cmd *cobra.Command, client *api.Client, out documentOutput, numbers []string, ndjson bool) error {
+	ctx, cancel := context.WithCancel(commandContext(cmd))
+	defer cancel()
+
+	workers := documentConcurrency(cmd)
+
+	results := fetchOrdered(ctx, len(numbers), workers, func(i int) documentResult {
+		return fetchDocumentText(ctx, client, out.format, numbers[i])
+	})
+
+	// Failed documents do not fail the write: an --output file keeps the
+	// documents that were fetched.
+	var (
+		failed    int
+		firstFail error
+	)
+	err := out.write(cmd, func(w io.Writer) error {
+		stream := format.NewTextDocumentStream(w)
+		if useJSON(cmd) {
+			stream = format.NewJSONDocumentStream(w, ndjson)
+		}
+
+		s := startSpinner(cmd, fmt.Sprintf("Lade %d Dokumente...", len(numbers)))
+		defer func() { stopSpinner(s) }()
+
+		for _, nr := range numbers {
+			res, err := results.next(ctx)
+			if err != nil {
+				return err
+			}
+			stopSpinner(s)
+			s = nil
+
+			if errors.Is(res.err, context.Canceled) {
+				return res.err
+			}
+			if res.err != nil {
+				failed++
+				if firstFail == nil {
+					firstFail = res.err
+				}
+				if err := stream.Failed(nr, documentError{res.err}); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := stream.Document(res.doc, res.content); err != nil {
+				return err
+			}
+		}
+
+		return stream.End()
+	})
+	if err == nil && failed > 0 {
+		return errWrap(firstFail, "Fehler: %d von %d Dokumenten konnten nicht abgerufen werden", failed, len(numbers))
+	}
+	return err
+}
+
+// fetchDocumentText fetches a document of a batch with its metadata and
+// converts its content to text.
+func fetchDocumentText(ctx context.Context, client *api.Client, docFormat documentFormat, docNumber string) documentResult {
+	if err := validateDocNumber(docNumber); err != nil {
+		return documentResult{err: errValidation("Fehler: %v", err)}
+	}
+
+	src, err := openDocument(ctx, client, docFormat, docNumber)
+	if err != nil {
+		return documentResult{err: err}
+	}
+	if src.body == nil {
+		doc, err := src.meta()
+		return documentResult{doc: doc, err: err}
+	}
+	defer src.body.Close()
+
+	var text strings.Builder
+	if err := format.WriteHTMLText(&text, src.body); err != nil {
+		return documentResult{err: fmt.Errorf("Dokument konnte nicht gelesen werden: %w", err)}
+	}
+	doc, err := documentMetadata(src.meta, docNumber, src.url)
+	return documentResult{doc: doc, content: text.String(), err: err}
+}
+
+// documentError reports a failed document of a batch in JSON output with
+// the same fields as the command's own error object.
+type documentError struct {
+	error
+}
+
+func (e documentError) Unwrap() error { return e.error }
+
+func (e documentError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(classifyError(e.error))
+}
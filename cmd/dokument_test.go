@@ -0,0 +1,480 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/model"
+	"github.com/philrox/risgo/pkg/ristest"
+)
+
+// pdfSearchResponse is a search result whose only hit has an HTML rendition
+// at {{base}}Dokumente/Test/XYZ_12345.html and a PDF rendition next to it.
+const pdfSearchResponse = `{
+	"OgdSearchResult": {
+		"OgdDocumentResults": {
+			"Hits": {"#text": "1", "@pageNumber": "1", "@pageSize": "20"},
+			"OgdDocumentReference": {
+				"Data": {
+					"Metadaten": {
+						"Technisch": {"ID": "XYZ_12345", "Applikation": "Justiz"},
+						"Allgemein": {"DokumentUrl": "{{base}}Dokumente/Test/XYZ_12345.html"},
+						"Judikatur": {"Kurztitel": "1 Ob 1/20a", "Justiz": {"Entscheidungsdatum": "2020-01-28"}}
+					},
+					"Dokumentliste": {
+						"ContentReference": {
+							"ContentType": "MainDocument",
+							"Urls": {"ContentUrl": {"DataType": "Pdf", "Url": "{{base}}Dokumente/Test/XYZ_12345.pdf"}}
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
+// resetDokumentFlags restores the flags changed by the dokument tests.
+func resetDokumentFlags(t *testing.T) {
+	t.Cleanup(func() {
+		dokumentCmd.Flags().Set("format", "text")
+		dokumentCmd.Flags().Set("output", "")
+		dokumentCmd.Flags().Set("ndjson", "false")
+		noCache, jsonOutput = false, false
+	})
+}
+
+func TestDokument_InvalidFormat_ReturnsValidationError(t *testing.T) {
+	resetDokumentFlags(t)
+	err := executeCommand("dokument", "NOR40052761", "--format", "docx")
+	assertValidationError(t, err, "ungültiger Wert für --format")
+}
+
+func TestDokument_FormatPDF_WritesOutputFile(t *testing.T) {
+	pdfType := "application/pdf"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".pdf") {
+			w.Header().Set("Content-Type", pdfType)
+			w.Write([]byte("%PDF-1.7 test"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(strings.ReplaceAll(pdfSearchResponse, "{{base}}", "http://"+r.Host+"/")))
+	}))
+	defer srv.Close()
+	os.Setenv("RIS_BASE_URL", srv.URL+"/")
+	defer os.Unsetenv("RIS_BASE_URL")
+	resetDokumentFlags(t)
+
+	out := filepath.Join(t.TempDir(), "XYZ_12345.pdf")
+	if err := executeCommand("dokument", "XYZ_12345", "--format", "pdf", "--output", out, "--no-cache"); err != nil {
+		t.Fatalf("dokument --format pdf: %v", err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "%PDF-1.7 test" {
+		t.Errorf("output file = %q", got)
+	}
+
+	// An HTML error page must not end up in the file.
+	pdfType = "text/html; charset=utf-8"
+	out2 := filepath.Join(filepath.Dir(out), "error.pdf")
+	err = executeCommand("dokument", "XYZ_12345", "--format", "pdf", "--output", out2, "--no-cache")
+	var ctErr *api.ContentTypeError
+	if !errors.As(err, &ctErr) {
+		t.Fatalf("expected *api.ContentTypeError, got %T: %v", err, err)
+	}
+	if _, err := os.Stat(out2); !os.IsNotExist(err) {
+		t.Errorf("output file exists after failed download: %v", err)
+	}
+}
+
+func TestDokument_URL_MockServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.7 mock"))
+	}))
+	defer srv.Close()
+	os.Setenv("RIS_BASE_URL", srv.URL+"/")
+	defer os.Unsetenv("RIS_BASE_URL")
+	resetDokumentFlags(t)
+	defer dokumentCmd.Flags().Set("url", "")
+
+	// The client accepts the base URL's origin, even over plain HTTP.
+	out := filepath.Join(t.TempDir(), "mock.pdf")
+	if err := executeCommand("dokument", "--url", srv.URL+"/Dokumente/Bundesnormen/NOR1/NOR1.pdf", "--format", "pdf", "--output", out, "--no-cache"); err != nil {
+		t.Fatalf("dokument --url: %v", err)
+	}
+	if got, _ := os.ReadFile(out); string(got) != "%PDF-1.7 mock" {
+		t.Errorf("output file = %q", got)
+	}
+
+	// Other hosts are refused by the client before any request is made.
+	err := executeCommand("dokument", "--url", "https://example.com/doc.pdf", "--format", "pdf", "--output", out, "--no-cache")
+	assertValidationError(t, err, `Host "example.com" nicht erlaubt`)
+}
+
+// TestDokument_RecordReplay_BinaryRendition records a PDF download with
+// RIS_RECORD and replays it with RIS_REPLAY after the server is gone.
+func TestDokument_RecordReplay_BinaryRendition(t *testing.T) {
+	pdf := []byte{'%', 'P', 'D', 'F', 0xff, 0xfe, 0x00, 0x80}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".pdf") {
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write(pdf)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(strings.ReplaceAll(pdfSearchResponse, "{{base}}", "http://"+r.Host+"/")))
+	}))
+	os.Setenv("RIS_BASE_URL", srv.URL+"/")
+	defer os.Unsetenv("RIS_BASE_URL")
+	resetDokumentFlags(t)
+
+	cassettes := t.TempDir()
+	dir := t.TempDir()
+	download := func(name string) []byte {
+		t.Helper()
+		out := filepath.Join(dir, name)
+		if err := executeCommand("dokument", "XYZ_12345", "--format", "pdf", "--output", out, "--no-cache"); err != nil {
+			t.Fatalf("dokument --format pdf: %v", err)
+		}
+		got, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	t.Setenv("RIS_RECORD", cassettes)
+	download("recorded.pdf")
+	os.Unsetenv("RIS_RECORD")
+	srv.Close()
+
+	t.Setenv("RIS_REPLAY", cassettes)
+	if got := download("replayed.pdf"); !bytes.Equal(got, pdf) {
+		t.Errorf("replayed PDF = % x, want % x", got, pdf)
+	}
+}
+
+func TestDokument_JSONIncludesSearchMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".html") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<p>Volltext</p>"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(strings.ReplaceAll(pdfSearchResponse, "{{base}}", "http://"+r.Host+"/")))
+	}))
+	defer srv.Close()
+	os.Setenv("RIS_BASE_URL", srv.URL+"/")
+	defer os.Unsetenv("RIS_BASE_URL")
+	resetDokumentFlags(t)
+	defer func() { jsonOutput = false }()
+
+	out := filepath.Join(t.TempDir(), "doc.json")
+	if err := executeCommand("dokument", "XYZ_12345", "--json", "--output", out); err != nil {
+		t.Fatalf("dokument --json: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Metadata struct {
+			Kurztitel string `json:"kurztitel"`
+			Citation  struct {
+				Entscheidungsdatum string `json:"entscheidungsdatum"`
+			} `json:"citation"`
+		} `json:"metadata"`
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", data, err)
+	}
+	if got.Metadata.Kurztitel != "1 Ob 1/20a" || got.Metadata.Citation.Entscheidungsdatum != "2020-01-28" {
+		t.Errorf("metadata = %+v", got.Metadata)
+	}
+	if !strings.Contains(got.Content, "Volltext") {
+		t.Errorf("content = %q", got.Content)
+	}
+}
+
+func TestDokument_MockServer_SkipsDirectURL(t *testing.T) {
+	srv, err := ristest.NewServer(ristest.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	onlyContact(t, srv.Server)
+	os.Setenv("RIS_BASE_URL", srv.BaseURL())
+	defer os.Unsetenv("RIS_BASE_URL")
+	resetDokumentFlags(t)
+
+	// NOR numbers route to a public RIS host; against the mock server the
+	// document is fetched from the content URL of its search result instead.
+	out := filepath.Join(t.TempDir(), "doc.txt")
+	if err := executeCommand("dokument", "NOR12017681", "--no-cache", "--output", out); err != nil {
+		t.Fatalf("dokument: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "NOR12017681") {
+		t.Errorf("output does not show the document:\n%s", data)
+	}
+}
+
+func TestFetchOrdered_BoundsLookahead(t *testing.T) {
+	const n, workers = 20, 2
+	var started atomic.Int32
+	gate := make(chan struct{})
+	results := fetchOrdered(context.Background(), n, workers, func(i int) int {
+		started.Add(1)
+		if i == 0 {
+			<-gate // a slow first document
+		}
+		return i
+	})
+
+	time.Sleep(50 * time.Millisecond)
+	if got := started.Load(); got > 2*workers {
+		t.Errorf("%d fetches started while the first is pending, want at most %d", got, 2*workers)
+	}
+
+	close(gate)
+	for want := range n {
+		if got, err := results.next(context.Background()); err != nil || got != want {
+			t.Fatalf("next = %d, %v, want %d", got, err, want)
+		}
+	}
+}
+
+func TestDokument_BatchFromStdin_ReportsFailuresInPlace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".html") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<p>Volltext</p>"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		nr := r.URL.Query().Get("Dokumentnummer")
+		if nr == "XYZ_33333" {
+			w.Write([]byte(minimalAPIResponse))
+			return
+		}
+		resp := strings.ReplaceAll(pdfSearchResponse, "{{base}}", "http://"+r.Host+"/")
+		w.Write([]byte(strings.ReplaceAll(resp, "XYZ_12345", nr)))
+	}))
+	defer srv.Close()
+	os.Setenv("RIS_BASE_URL", srv.URL+"/")
+	defer os.Unsetenv("RIS_BASE_URL")
+	resetDokumentFlags(t)
+	rootCmd.SetIn(strings.NewReader("XYZ_11111\n\nXYZ_33333\n"))
+	defer rootCmd.SetIn(nil)
+
+	out := filepath.Join(t.TempDir(), "docs.ndjson")
+	err := executeCommand("dokument", "XYZ_22222", "-", "--ndjson", "--output", out)
+	if ExitCode(err) != ExitNotFound {
+		t.Fatalf("expected exit code %d, got %d: %v", ExitNotFound, ExitCode(err), err)
+	}
+	if strings.Count(err.Error(), "Fehler:") != 1 {
+		t.Errorf("error = %q, want a single Fehler: prefix", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 NDJSON lines, got %d:\n%s", len(lines), data)
+	}
+	for i, want := range []string{`"dokumentnummer":"XYZ_22222"`, `"dokumentnummer":"XYZ_11111"`, `"code":"not_found"`} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("line %d = %s, want %s", i+1, lines[i], want)
+		}
+	}
+}
+
+func TestDokument_OutputDir_WritesFilesAndManifest(t *testing.T) {
+	srv, err := ristest.NewServer(ristest.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	onlyContact(t, srv.Server)
+	os.Setenv("RIS_BASE_URL", srv.BaseURL())
+	defer os.Unsetenv("RIS_BASE_URL")
+	resetDokumentFlags(t)
+	defer func() { outputDir, nameTemplate, ifExists = "", "", "skip" }()
+
+	dir := t.TempDir()
+	args := []string{"dokument", "NOR12017681", "NOR12017682", "--output-dir", dir, "--name-template", "{{.Kurztitel}} {{.Citation.Paragraph}}.txt"}
+	if err := executeCommand(args...); err != nil {
+		t.Fatalf("dokument --output-dir: %v", err)
+	}
+
+	readManifest := func() manifest {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, manifestName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m manifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	m := readManifest()
+	if len(m.Files) != 2 {
+		t.Fatalf("manifest lists %d files, want 2", len(m.Files))
+	}
+	for _, f := range m.Files {
+		if f.Status != fileWritten || strings.ContainsAny(f.File, " /") {
+			t.Errorf("manifest entry = %+v", f)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.File))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(len(data)) != f.Bytes || !strings.Contains(string(data), f.Dokumentnummer) {
+			t.Errorf("%s: %d bytes, manifest says %d:\n%s", f.File, len(data), f.Bytes, data)
+		}
+	}
+
+	// A second run leaves the existing files alone.
+	if err := executeCommand(args...); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	for _, f := range readManifest().Files {
+		if f.Status != fileSkipped {
+			t.Errorf("second run: %s status = %q, want %q", f.File, f.Status, fileSkipped)
+		}
+	}
+}
+
+func TestDokument_OutputDir_NameCollisionsFollowJobOrder(t *testing.T) {
+	srv, err := ristest.NewServer(ristest.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	onlyContact(t, srv.Server)
+	os.Setenv("RIS_BASE_URL", srv.BaseURL())
+	defer os.Unsetenv("RIS_BASE_URL")
+	resetDokumentFlags(t)
+	defer func() { outputDir, nameTemplate, ifExists, concurrency = "", "", "skip", 1 }()
+
+	// Both documents render to the same name; the later job gets its number
+	// appended, however the lookups are scheduled.
+	for range 5 {
+		dir := t.TempDir()
+		err := executeCommand("dokument", "NOR12017682", "NOR12017681", "--output-dir", dir,
+			"--name-template", "gesetz.txt", "--concurrency", "4")
+		if err != nil {
+			t.Fatalf("dokument --output-dir: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, manifestName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m manifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, f := range m.Files {
+			got = append(got, f.Dokumentnummer+"="+f.File)
+		}
+		want := []string{"NOR12017682=gesetz.txt", "NOR12017681=gesetz_NOR12017681.txt"}
+		if !slices.Equal(got, want) {
+			t.Fatalf("files = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSearchToDir_FailedPageFailsRun(t *testing.T) {
+	srv, err := ristest.NewServer(ristest.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("Seitennummer") == "2" {
+			http.Error(w, "kaputt", http.StatusInternalServerError)
+			return
+		}
+		srv.Handler.ServeHTTP(w, r)
+	}))
+	defer broken.Close()
+	onlyContact(t, broken)
+
+	cmd := setupTestCmd(broken.URL + "/")
+	defer os.Unsetenv("RIS_BASE_URL")
+	cmd.PersistentFlags().Set("limit", "10")
+	dir := t.TempDir()
+	allPages, outputDir, quiet, concurrency = true, dir, true, 3
+	oldRate := rateLimit
+	rateLimit = 0
+	defer func() { allPages, outputDir, quiet, concurrency, rateLimit = false, "", false, 1, oldRate }()
+
+	err = executeSearch(cmd, "Bundesrecht", "Suche...", api.NewParams())
+	if err == nil || !strings.Contains(err.Error(), "1 Seite(n) konnten nicht geladen werden (2), Ergebnis unvollständig") {
+		t.Fatalf("err = %v, want the missing page reported", err)
+	}
+	if strings.Contains(err.Error(), ": Fehler:") {
+		t.Errorf("err = %q, want no repeated Fehler: prefix", err)
+	}
+
+	// The documents of the other pages are still saved and listed.
+	data, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Files) != 14 {
+		t.Errorf("manifest lists %d files, want 14 (pages 1 and 3)", len(m.Files))
+	}
+}
+
+func TestFileSaver_Open_ReportsFirstError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	client, err := api.NewClient(api.ClientOptions{BaseURL: srv.URL + "/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The content URL answers 404; the derived fallback on the public host
+	// fails differently (no network in tests), but the 404 is what counts.
+	saver := &fileSaver{format: documentFormats[1]} // html
+	doc := model.Document{Dokumentnummer: "NOR12017681", ContentURLs: model.ContentURLs{HTML: srv.URL + "/NOR12017681.html"}}
+	_, _, err = saver.open(context.Background(), client, doc)
+	var httpErr *api.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v, want the 404 of the content URL", err)
+	}
+	if ExitCode(err) != ExitNotFound {
+		t.Errorf("ExitCode = %d, want %d", ExitCode(err), ExitNotFound)
+	}
+}
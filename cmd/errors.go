@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/philrox/risgo/internal/api"
+)
+
+// Exit codes. They are part of the CLI's stable interface (see README).
+const (
+	ExitOK         = 0
+	ExitError      = 1
+	ExitValidation = 2
+	ExitNotFound   = 3
+	ExitUpstream   = 4
+	ExitTimeout    = 5
+	ExitEmpty      = 6
+	ExitCanceled   = 130
+)
+
+// NotFoundError indicates that a requested document does not exist.
+type NotFoundError struct {
+	msg string
+}
+
+func (e *NotFoundError) Error() string { return e.msg }
+
+// errNotFound creates a not-found error with fmt.Sprintf formatting.
+func errNotFound(format string, args ...any) error {
+	return &NotFoundError{msg: fmt.Sprintf(format, args...)}
+}
+
+// wrappedError is an error message followed by the message of its cause.
+// The cause's "Fehler: " prefix is dropped so that it is not repeated.
+type wrappedError struct {
+	msg   string
+	cause error
+}
+
+func (e *wrappedError) Error() string {
+	return e.msg + ": " + strings.TrimPrefix(e.cause.Error(), "Fehler: ")
+}
+
+func (e *wrappedError) Unwrap() error { return e.cause }
+
+// errWrap is like fmt.Errorf with a trailing ": %w" for cause, but without
+// a second "Fehler: " prefix in the message.
+func errWrap(cause error, format string, args ...any) error {
+	return &wrappedError{msg: fmt.Sprintf(format, args...), cause: cause}
+}
+
+// EmptyResultError indicates a search without hits when --fail-empty is set.
+type EmptyResultError struct{}
+
+func (e *EmptyResultError) Error() string {
+	return "Fehler: keine Ergebnisse gefunden (--fail-empty)"
+}
+
+// errorInfo is the machine-readable description of an error, written as
+// {"error": {...}} to stderr in --json mode.
+type errorInfo struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+	URL        string `json:"url,omitempty"`
+	// Applikation is the collection an api_error refers to.
+	Applikation string `json:"applikation,omitempty"`
+	Retryable   bool   `json:"retryable"`
+	ExitCode    int    `json:"exit_code"`
+}
+
+// classifyError maps an error to its error code and exit code.
+func classifyError(err error) errorInfo {
+	info := errorInfo{
+		Code:     "error",
+		Message:  strings.TrimPrefix(err.Error(), "Fehler: "),
+		ExitCode: ExitError,
+	}
+
+	var (
+		validationErr *ValidationError
+		notFoundErr   *NotFoundError
+		emptyErr      *EmptyResultError
+		timeoutErr    *api.TimeoutError
+		httpErr       *api.HTTPError
+		requestErr    *api.RequestError
+		offlineErr    *api.OfflineError
+		replayErr     *api.ReplayMissError
+		redirectErr   *api.RedirectError
+		urlErr        *api.URLError
+		tooLargeErr   *api.ResponseTooLargeError
+		mediaTypeErr  *api.ContentTypeError
+		apiErr        *api.APIError
+		syntaxErr     *json.SyntaxError
+		typeErr       *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.Is(err, context.Canceled):
+		info.Code, info.Message, info.ExitCode = "canceled", "Abgebrochen.", ExitCanceled
+	case errors.As(err, &validationErr):
+		info.Code, info.ExitCode = "validation", ExitValidation
+	case errors.As(err, &notFoundErr):
+		info.Code, info.ExitCode = "not_found", ExitNotFound
+	case errors.As(err, &apiErr):
+		info.Code, info.Message, info.ExitCode = "api_error", apiErr.Message, ExitValidation
+		info.Applikation = apiErr.Applikation
+	case errors.As(err, &emptyErr):
+		info.Code, info.ExitCode = "empty_result", ExitEmpty
+	case errors.As(err, &timeoutErr):
+		info.Code, info.URL, info.Retryable, info.ExitCode = "timeout", timeoutErr.URL, true, ExitTimeout
+	case errors.Is(err, context.DeadlineExceeded):
+		info.Code, info.ExitCode = "timeout", ExitTimeout
+	case errors.As(err, &httpErr):
+		info.Code, info.URL, info.HTTPStatus = "http_error", httpErr.URL, httpErr.StatusCode
+		info.Retryable, info.ExitCode = httpErr.Retryable(), ExitUpstream
+		if httpErr.StatusCode == 404 {
+			info.Code, info.ExitCode = "not_found", ExitNotFound
+		}
+	case errors.As(err, &replayErr):
+		info.Code, info.URL, info.ExitCode = "replay_miss", replayErr.URL, ExitNotFound
+	case errors.As(err, &tooLargeErr):
+		info.Code, info.URL, info.ExitCode = "response_too_large", tooLargeErr.URL, ExitUpstream
+	case errors.As(err, &mediaTypeErr):
+		info.Code, info.URL, info.ExitCode = "unexpected_content_type", mediaTypeErr.URL, ExitUpstream
+	case errors.As(err, &redirectErr):
+		info.Code, info.URL, info.ExitCode = "redirect_rejected", redirectErr.URL, ExitUpstream
+	case errors.As(err, &urlErr):
+		// A refused --url is reported as a validation error by the dokument
+		// command; here the URL came from the API's own metadata.
+		info.Code, info.URL, info.ExitCode = "url_rejected", urlErr.URL, ExitUpstream
+	case errors.As(err, &requestErr):
+		info.Code, info.URL, info.Retryable, info.ExitCode = "request_failed", requestErr.URL, requestErr.Retryable(), ExitUpstream
+	case errors.As(err, &offlineErr):
+		info.Code, info.URL, info.ExitCode = "offline_miss", offlineErr.URL, ExitNotFound
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		info.Code, info.ExitCode = "invalid_response", ExitUpstream
+	}
+	return info
+}
+
+// ExitCode returns the process exit code for err (0 for nil).
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	return classifyError(err).ExitCode
+}
+
+// WriteError reports err to w: as a JSON error object in --json mode,
+// otherwise as plain text.
+func WriteError(w io.Writer, err error) {
+	info := classifyError(err)
+	if !jsonOutput {
+		if info.Code == "canceled" {
+			fmt.Fprintln(w, info.Message)
+		} else {
+			fmt.Fprintln(w, err)
+		}
+		return
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	enc.Encode(struct {
+		Error errorInfo `json:"error"`
+	}{info})
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/philrox/risgo/internal/api"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+		wantExit int
+	}{
+		{"validation", errValidation("Fehler: --app ist erforderlich"), "validation", ExitValidation},
+		{"not found", errNotFound("Fehler: Dokument %q nicht gefunden", "NOR1"), "not_found", ExitNotFound},
+		{"api error", &api.APIError{Applikation: "Bundesnormen", Message: "Seitennummer ungültig"}, "api_error", ExitValidation},
+		{"empty", &EmptyResultError{}, "empty_result", ExitEmpty},
+		{"timeout", fmt.Errorf("API-Anfrage fehlgeschlagen: %w", &api.TimeoutError{URL: "u"}), "timeout", ExitTimeout},
+		{"deadline", fmt.Errorf("x: %w", context.DeadlineExceeded), "timeout", ExitTimeout},
+		{"http 503", &api.HTTPError{StatusCode: 503, URL: "u"}, "http_error", ExitUpstream},
+		{"http 404", &api.HTTPError{StatusCode: 404, URL: "u"}, "not_found", ExitNotFound},
+		{"request", &api.RequestError{URL: "u", Err: errors.New("refused")}, "request_failed", ExitUpstream},
+		{"replay miss", &api.RequestError{URL: "u", Err: &api.ReplayMissError{URL: "u"}}, "replay_miss", ExitNotFound},
+		{"redirect", &api.RequestError{URL: "u", Err: &url.Error{Op: "Get", URL: "u", Err: &api.RedirectError{URL: "https://evil.com/"}}}, "redirect_rejected", ExitUpstream},
+		{"url rejected", fmt.Errorf("x: %w", &api.URLError{URL: "http://evil.com/x.pdf", Reason: "nur HTTPS erlaubt"}), "url_rejected", ExitUpstream},
+		{"url rejected via --url", errValidation("Fehler: %v", &api.URLError{URL: "http://evil.com/x.pdf", Reason: "nur HTTPS erlaubt"}), "validation", ExitValidation},
+		{"too large", fmt.Errorf("x: %w", &api.ResponseTooLargeError{URL: "u", Limit: 1 << 20}), "response_too_large", ExitUpstream},
+		{"content type", fmt.Errorf("x: %w", &api.ContentTypeError{URL: "u", ContentType: "text/html", Want: []string{"application/pdf"}}), "unexpected_content_type", ExitUpstream},
+		{"offline", &api.OfflineError{URL: "u"}, "offline_miss", ExitNotFound},
+		{"invalid response", fmt.Errorf("x: %w", &json.SyntaxError{}), "invalid_response", ExitUpstream},
+		{"canceled", fmt.Errorf("x: %w", context.Canceled), "canceled", ExitCanceled},
+		{"generic", errors.New("kaputt"), "error", ExitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.wantExit {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.wantExit)
+			}
+			if got := classifyError(tt.err).Code; got != tt.wantCode {
+				t.Errorf("code = %q, want %q", got, tt.wantCode)
+			}
+		})
+	}
+	if ExitCode(nil) != ExitOK {
+		t.Error("ExitCode(nil) should be 0")
+	}
+}
+
+func TestWriteError_JSON(t *testing.T) {
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+
+	var buf bytes.Buffer
+	WriteError(&buf, fmt.Errorf("API-Anfrage fehlgeschlagen: %w", &api.HTTPError{StatusCode: 503, Status: "503 Service Unavailable", URL: "https://data.bka.gv.at/x"}))
+
+	var parsed struct {
+		Error struct {
+			Code       string `json:"code"`
+			Message    string `json:"message"`
+			HTTPStatus int    `json:"http_status"`
+			URL        string `json:"url"`
+			Retryable  bool   `json:"retryable"`
+			ExitCode   int    `json:"exit_code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	e := parsed.Error
+	if e.Code != "http_error" || e.HTTPStatus != 503 || e.URL != "https://data.bka.gv.at/x" || !e.Retryable || e.ExitCode != ExitUpstream {
+		t.Errorf("unexpected error object: %+v", e)
+	}
+	if !strings.HasPrefix(e.Message, "API-Anfrage fehlgeschlagen") {
+		t.Errorf("message = %q", e.Message)
+	}
+}
+
+func TestWriteError_Text(t *testing.T) {
+	var buf bytes.Buffer
+	WriteError(&buf, errValidation("Fehler: --app ist erforderlich"))
+	if buf.String() != "Fehler: --app ist erforderlich\n" {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestUnknownFlag_ReturnsValidationError(t *testing.T) {
+	err := executeCommand("bundesrecht", "--gibtsnicht")
+	assertValidationError(t, err, "unknown flag")
+}
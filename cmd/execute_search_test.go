@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/pkg/ristest"
 	"github.com/spf13/cobra"
 )
 
@@ -64,6 +66,26 @@ func setupTestCmd(baseURL string) *cobra.Command {
 	return cmd
 }
 
+// onlyContact routes the commands' requests through a proxy that passes
+// those for srv on to its handler and fails the test on any other host,
+// e.g. a public RIS host.
+func onlyContact(t *testing.T, srv *httptest.Server) {
+	t.Helper()
+	host := strings.TrimPrefix(srv.URL, "http://")
+	guard := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodConnect || r.Host != host {
+			t.Errorf("%s request to %s, want only %s", r.Method, r.Host, srv.URL)
+			http.Error(w, "host not allowed in tests", http.StatusForbidden)
+			return
+		}
+		srv.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(guard.Close)
+	old := proxyURL
+	proxyURL = guard.URL
+	t.Cleanup(func() { proxyURL = old })
+}
+
 func TestExecuteSearch_Success_TextOutput(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify endpoint path.
@@ -208,3 +230,84 @@ func TestExecuteSearch_InvalidLimit(t *testing.T) {
 		t.Errorf("expected error to mention --limit, got: %v", err)
 	}
 }
+
+func TestExecuteSearch_CancelledContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server after cancellation")
+	}))
+	defer srv.Close()
+
+	cmd := setupTestCmd(srv.URL)
+	defer os.Unsetenv("RIS_BASE_URL")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cmd.SetContext(ctx)
+
+	params := api.NewParams()
+	params.Set("Suchworte", "cancelled")
+
+	err := executeSearch(cmd, "Bundesrecht", "Suche...", params)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %T: %v", err, err)
+	}
+}
+
+func TestExecuteSearch_AllPages(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxResults   int
+		wantRequests int
+	}{
+		{"unbounded", 0, 3},
+		{"capped", 15, 2},
+		{"capped at page boundary", 10, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := ristest.NewServer(ristest.Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer srv.Close()
+			onlyContact(t, srv.Server)
+
+			cmd := setupTestCmd(srv.BaseURL())
+			defer os.Unsetenv("RIS_BASE_URL")
+			cmd.PersistentFlags().Set("limit", "10")
+
+			allPages, maxResults = true, tt.maxResults
+			defer func() { allPages, maxResults = false, 1000 }()
+
+			if err := executeSearch(cmd, "Bundesrecht", "Suche...", api.NewParams()); err != nil {
+				t.Fatalf("executeSearch returned error: %v", err)
+			}
+			if got := srv.Handler.Requests(); got != tt.wantRequests {
+				t.Errorf("server saw %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestExecuteSearch_FailEmpty(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(minimalAPIResponse))
+	}))
+	defer srv.Close()
+
+	cmd := setupTestCmd(srv.URL)
+	defer os.Unsetenv("RIS_BASE_URL")
+
+	failEmpty = true
+	defer func() { failEmpty = false }()
+
+	err := executeSearch(cmd, "Bundesrecht", "Suche...", api.NewParams())
+	var empty *EmptyResultError
+	if !errors.As(err, &empty) {
+		t.Fatalf("expected *EmptyResultError, got %T: %v", err, err)
+	}
+	if ExitCode(err) != ExitEmpty {
+		t.Errorf("ExitCode = %d, want %d", ExitCode(err), ExitEmpty)
+	}
+}
@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"strings"
-
-	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -42,98 +39,23 @@ func init() {
 }
 
 func runGemeinden(cmd *cobra.Command, args []string) error {
-	search, _ := cmd.Flags().GetString("search")
-	title, _ := cmd.Flags().GetString("title")
-	state, _ := cmd.Flags().GetString("state")
-	municipality, _ := cmd.Flags().GetString("municipality")
-	fileNumber, _ := cmd.Flags().GetString("file-number")
-	index, _ := cmd.Flags().GetString("index")
-	district, _ := cmd.Flags().GetString("district")
-	gemeindeverband, _ := cmd.Flags().GetString("gemeindeverband")
-	announcementNr, _ := cmd.Flags().GetString("announcement-nr")
-	app, _ := cmd.Flags().GetString("app")
-	date, _ := cmd.Flags().GetString("date")
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	since, _ := cmd.Flags().GetString("since")
-	sortDir, _ := cmd.Flags().GetString("sort-dir")
-	sortBy, _ := cmd.Flags().GetString("sort-by")
-
-	// At least one required.
-	if search == "" && title == "" && state == "" && municipality == "" &&
-		fileNumber == "" && index == "" && district == "" && gemeindeverband == "" && announcementNr == "" {
-		return errValidation("Fehler: mindestens ein Suchparameter erforderlich")
-	}
-
-	appValue, ok := constants.GemeindenApps[strings.ToLower(app)]
-	if !ok {
-		return errValidation("Fehler: ungültiger --app Wert %q (gültig: gr, gra)", app)
-	}
-
-	params := api.NewParams()
-	params.Set("Applikation", appValue)
-
-	if search != "" {
-		params.Set("Suchworte", search)
-	}
-	if title != "" {
-		params.Set("Titel", title)
-	}
-	if state != "" {
-		params.Set("Bundesland", state)
-	}
-	if municipality != "" {
-		params.Set("Gemeinde", municipality)
-	}
-	if fileNumber != "" {
-		params.Set("Geschaeftszahl", fileNumber)
-	}
-	if index != "" {
-		value, ok := constants.GemeindenIndex[strings.ToLower(index)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --index Wert %q", index)
-		}
-		params.Set("Index", value)
-	}
-	if district != "" {
-		params.Set("Bezirk", district)
-	}
-	if gemeindeverband != "" {
-		params.Set("Gemeindeverband", gemeindeverband)
-	}
-	if announcementNr != "" {
-		params.Set("Kundmachungsnummer", announcementNr)
-	}
-	if date != "" {
-		params.Set("FassungVom", date)
-	}
-	if from != "" {
-		params.Set("Kundmachungsdatum.Von", from)
-	}
-	if to != "" {
-		params.Set("Kundmachungsdatum.Bis", to)
-	}
-	if since != "" {
-		value, ok := constants.ImRisSeit[strings.ToLower(since)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --since Wert %q", since)
-		}
-		params.Set("ImRisSeit", value)
-	}
-	if sortDir != "" {
-		value, ok := constants.SortDirections[strings.ToLower(sortDir)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --sort-dir Wert %q (gültig: asc, desc)", sortDir)
-		}
-		params.Set("Sortierung.SortDirection", value)
-	}
-	if sortBy != "" {
-		value, ok := constants.GemeindenSortColumns[strings.ToLower(sortBy)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --sort-by Wert %q (gültig: geschaeftszahl, bundesland, gemeinde)", sortBy)
-		}
-		params.Set("Sortierung.SortedByColumn", value)
-	}
-
-	return executeSearch(cmd, "Gemeinden", "Suche in Gemeinderecht...", params)
+	var q query.GemeindenQuery
+	q.Search, _ = cmd.Flags().GetString("search")
+	q.Title, _ = cmd.Flags().GetString("title")
+	q.State, _ = cmd.Flags().GetString("state")
+	q.Municipality, _ = cmd.Flags().GetString("municipality")
+	q.FileNumber, _ = cmd.Flags().GetString("file-number")
+	q.Index, _ = cmd.Flags().GetString("index")
+	q.District, _ = cmd.Flags().GetString("district")
+	q.Gemeindeverband, _ = cmd.Flags().GetString("gemeindeverband")
+	q.AnnouncementNr, _ = cmd.Flags().GetString("announcement-nr")
+	q.App, _ = cmd.Flags().GetString("app")
+	q.Date, _ = cmd.Flags().GetString("date")
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.Since, _ = cmd.Flags().GetString("since")
+	q.SortDir, _ = cmd.Flags().GetString("sort-dir")
+	q.SortBy, _ = cmd.Flags().GetString("sort-by")
+
+	return executeQuery(cmd, q, "Suche in Gemeinderecht...")
 }
@@ -1,14 +1,24 @@
 package cmd
 
 import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/briandowns/spinner"
 	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/cache"
 	"github.com/philrox/risgo/internal/constants"
 	"github.com/philrox/risgo/internal/format"
+	"github.com/philrox/risgo/internal/model"
 	"github.com/philrox/risgo/internal/parser"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/philrox/risgo/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -26,12 +36,144 @@ func errValidation(format string, args ...any) error {
 	return &ValidationError{msg: fmt.Sprintf(format, args...)}
 }
 
-// newClient creates an API client from the root command's global flags.
-func newClient(cmd *cobra.Command) *api.Client {
-	return api.NewClient(api.ClientOptions{
-		Timeout: timeout,
-		Verbose: verbose,
+// commandContext returns the command's context, falling back to
+// context.Background() when the command was not started via Execute.
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// newClient creates an API client from the root command's global flags and
+// the RIS_BASE_URL, RIS_RECORD, RIS_REPLAY and RIS_REPLAY_MATCH environment
+// variables. Invalid proxy or TLS settings are reported as validation errors.
+func newClient(cmd *cobra.Command) (*api.Client, error) {
+	tlsMin, err := parseTLSVersion(tlsMinVersion)
+	if err != nil {
+		return nil, err
+	}
+	client, err := api.NewClient(api.ClientOptions{
+		BaseURL:   os.Getenv("RIS_BASE_URL"),
+		Timeout:   timeout,
+		Verbose:   verbose,
+		Cache:     newCache(),
+		CacheMode: cacheMode(),
+		Retries:   retries,
+
+		RateLimit:     rateLimit,
+		RateBurst:     rateBurst,
+		RateLimitFile: rateLimitFile(),
+
+		RecordDir:   os.Getenv("RIS_RECORD"),
+		ReplayDir:   os.Getenv("RIS_REPLAY"),
+		ReplayMatch: replayMatch(),
+
+		ProxyURL:        proxyURL,
+		CAFiles:         caFiles,
+		ClientCert:      clientCert,
+		ClientKey:       clientKey,
+		TLSMinVersion:   tlsMin,
+		UserAgent:       cmp.Or(userAgent, api.UserAgent(version)),
+		AllowedHosts:    allowHosts,
+		MaxResponseSize: maxResponseSize(),
+
+		Trace: traceWriter(),
+		HAR:   harRecorder,
 	})
+	if err != nil {
+		return nil, errValidation("Fehler: %v", err)
+	}
+	return client, nil
+}
+
+// maxResponseSize maps --max-response-mb to ClientOptions.MaxResponseSize,
+// where 0 means unlimited.
+func maxResponseSize() int64 {
+	if maxResponseMB == 0 {
+		return -1
+	}
+	return int64(maxResponseMB) << 20
+}
+
+// replayMatch returns the replay matching mode from RIS_REPLAY_MATCH.
+// Unknown values are ignored like invalid values of the other RIS_*
+// variables (see applyEnv) and reported with --verbose.
+func replayMatch() api.ReplayMatch {
+	value := os.Getenv("RIS_REPLAY_MATCH")
+	switch match := api.ReplayMatch(strings.ToLower(strings.TrimSpace(value))); match {
+	case "", api.MatchStrict, api.MatchLenient:
+		return match
+	}
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Warnung: RIS_REPLAY_MATCH=%q ignoriert: gültig sind %s und %s\n", value, api.MatchStrict, api.MatchLenient)
+	}
+	return api.MatchStrict
+}
+
+// parseTLSVersion maps --tls-min to a crypto/tls version constant (0 = default).
+func parseTLSVersion(v string) (uint16, error) {
+	switch v {
+	case "":
+		return 0, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, errValidation("Fehler: ungültiger --tls-min Wert %q (erlaubt: 1.2, 1.3)", v)
+}
+
+// traceWriter returns stderr when --trace is set, nil otherwise.
+func traceWriter() io.Writer {
+	if !traceHTTP {
+		return nil
+	}
+	return os.Stderr
+}
+
+// rateLimitFile returns the shared rate limit state file inside the cache
+// directory when --rate-shared is set, or "" for a process-local budget.
+func rateLimitFile() string {
+	if !rateShared {
+		return ""
+	}
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		if isVerbose() {
+			fmt.Fprintf(os.Stderr, "Geteilte Ratenbegrenzung deaktiviert: %v\n", err)
+		}
+		return ""
+	}
+	return filepath.Join(dir, "ratelimit.state")
+}
+
+// newCache opens the response cache unless --no-cache is set.
+// Returns nil (caching disabled) if no cache directory can be determined.
+func newCache() *cache.Cache {
+	if noCache {
+		return nil
+	}
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		if isVerbose() {
+			fmt.Fprintf(os.Stderr, "Cache deaktiviert: %v\n", err)
+		}
+		return nil
+	}
+	return cache.New(dir)
+}
+
+// cacheMode maps the --refresh and --offline flags to an api.CacheMode.
+func cacheMode() api.CacheMode {
+	switch {
+	case offline:
+		return api.CacheOffline
+	case refresh:
+		return api.CacheRefresh
+	default:
+		return api.CacheDefault
+	}
 }
 
 // useJSON returns true if --json flag is set on the root command.
@@ -73,23 +215,154 @@ func executeSearch(cmd *cobra.Command, endpoint, spinnerMsg string, params *api.
 		return err
 	}
 
-	client := newClient(cmd)
+	if outputDir != "" {
+		return executeSearchToDir(cmd, endpoint, spinnerMsg, params)
+	}
+	if allPages {
+		return executeSearchAll(cmd, endpoint, spinnerMsg, params)
+	}
+
+	client, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
 	s := startSpinner(cmd, spinnerMsg)
-	body, err := client.Search(endpoint, params)
+	result, err := searchPage(commandContext(cmd), client, endpoint, params)
 	stopSpinner(s)
 	if err != nil {
-		return fmt.Errorf("API-Anfrage fehlgeschlagen: %w", err)
+		return err
+	}
+
+	if useJSON(cmd) {
+		err = format.JSON(os.Stdout, result)
+	} else {
+		err = format.Text(os.Stdout, result)
+	}
+	if err == nil && failEmpty && len(result.Documents) == 0 {
+		return &EmptyResultError{}
+	}
+	return err
+}
+
+// searchPage requests a single result page and decodes it. Errors reported
+// by the server (*api.APIError) are returned as is; their message speaks for
+// itself.
+func searchPage(ctx context.Context, client *api.Client, endpoint string, params *api.Params) (model.SearchResult, error) {
+	body, err := client.OpenSearch(ctx, endpoint, params)
+	if err != nil {
+		return model.SearchResult{}, fmt.Errorf("API-Anfrage fehlgeschlagen: %w", err)
+	}
+	defer body.Close()
+	result, err := parser.DecodeSearchResponse(body)
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		return model.SearchResult{}, err
+	}
+	if err != nil {
+		return model.SearchResult{}, fmt.Errorf("Antwort konnte nicht verarbeitet werden: %w", err)
+	}
+	return result, nil
+}
+
+// pageFailures collects the pages that failed during a walk with --all.
+type pageFailures struct {
+	pages []int
+	first error
+}
+
+// add records a failed page and reports it on stderr.
+func (f *pageFailures) add(pageErr *api.PageError) {
+	f.pages = append(f.pages, pageErr.Page)
+	if f.first == nil {
+		f.first = pageErr.Err
+	}
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Warnung: %v\n", pageErr)
+	}
+}
+
+// err returns the error ending a walk with failed pages, or nil.
+func (f *pageFailures) err() error {
+	if n := len(f.pages); n > 0 {
+		return fmt.Errorf("Fehler: %d Seite(n) konnten nicht geladen werden (%s), Ergebnis unvollständig: %w", n, format.JoinInts(f.pages), f.first)
 	}
+	return nil
+}
 
-	result, err := parser.ParseSearchResponse(body)
+// executeSearchAll walks all result pages (--all) and streams the documents
+// to stdout as they arrive, stopping after --max-results documents. With
+// --concurrency > 1 pages are fetched in parallel; pages that fail are
+// reported and skipped instead of aborting the whole walk.
+func executeSearchAll(cmd *cobra.Command, endpoint, spinnerMsg string, params *api.Params) error {
+	client, err := newClient(cmd)
 	if err != nil {
-		return fmt.Errorf("Antwort konnte nicht verarbeitet werden: %w", err)
+		return err
 	}
 
+	stream := format.NewTextStream(os.Stdout)
 	if useJSON(cmd) {
-		return format.JSON(os.Stdout, result)
+		stream = format.NewJSONStream(os.Stdout)
 	}
-	return format.Text(os.Stdout, result)
+
+	s := startSpinner(cmd, spinnerMsg)
+	defer func() { stopSpinner(s) }()
+
+	var (
+		count    int
+		started  bool
+		summary  format.StreamSummary
+		failures pageFailures
+	)
+	for result, err := range client.SearchPagesConcurrent(commandContext(cmd), endpoint, params, concurrency) {
+		stopSpinner(s)
+		s = nil
+
+		var pageErr *api.PageError
+		if errors.As(err, &pageErr) {
+			failures.add(pageErr)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("API-Anfrage fehlgeschlagen: %w", err)
+		}
+		if !started {
+			if err := stream.Begin(result.TotalHits); err != nil {
+				return err
+			}
+			started = true
+		}
+
+		docs := result.Documents
+		if maxResults > 0 && count+len(docs) > maxResults {
+			docs = docs[:maxResults-count]
+		}
+		for _, doc := range docs {
+			if err := stream.Document(doc); err != nil {
+				return err
+			}
+		}
+		count += len(docs)
+
+		if maxResults > 0 && count >= maxResults {
+			summary.HasMore = len(docs) < len(result.Documents) || result.HasMore
+			break
+		}
+		if isVerbose() && result.HasMore {
+			fmt.Fprintf(os.Stderr, "Seite %d: %d von %d Ergebnissen geladen\n", result.Page, count, result.TotalHits)
+		}
+	}
+
+	summary.FailedPages = failures.pages
+	if err := stream.End(summary); err != nil {
+		return err
+	}
+	if err := failures.err(); err != nil {
+		return err
+	}
+	if failEmpty && count == 0 {
+		return &EmptyResultError{}
+	}
+	return nil
 }
 
 // setPageParams sets pagination parameters from the root command's global flags.
@@ -99,14 +372,32 @@ func setPageParams(cmd *cobra.Command, params *api.Params) error {
 	page, _ := root.PersistentFlags().GetInt("page")
 	limit, _ := root.PersistentFlags().GetInt("limit")
 
-	if page > 0 {
-		params.Set("Seitennummer", fmt.Sprintf("%d", page))
-	}
-
-	pageSize, ok := constants.PageSizes[limit]
-	if !ok {
+	// Size 0 would leave the page size to the API; --limit always sets it.
+	if _, ok := constants.PageSizes[limit]; !ok {
 		return errValidation("ungültiger Wert für --limit: %d (erlaubt: 10, 20, 50, 100)", limit)
 	}
-	params.Set("DokumenteProSeite", pageSize)
+	var qErr *query.Error
+	if err := (query.Paging{Page: page, Size: limit}).Apply(params); errors.As(err, &qErr) {
+		return errValidation("Fehler: %s", qErr.Format(func(string) string { return "--page" }))
+	}
 	return nil
 }
+
+// executeQuery validates q and runs the common search pipeline for it.
+func executeQuery(cmd *cobra.Command, q query.Query, spinnerMsg string) error {
+	params, err := q.Params()
+	if err != nil {
+		return queryError(err)
+	}
+	return executeSearch(cmd, q.Endpoint(), spinnerMsg, params)
+}
+
+// queryError converts a query validation error into a ValidationError that
+// refers to the command's flags.
+func queryError(err error) error {
+	var qErr *query.Error
+	if errors.As(err, &qErr) {
+		return errValidation("Fehler: %s", qErr.Format(func(field string) string { return "--" + query.FlagName(field) }))
+	}
+	return err
+}
@@ -1,10 +1,13 @@
 package cmd
 
 import (
-	"strings"
+	"errors"
+	"fmt"
 
 	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/format"
+	"github.com/philrox/risgo/internal/model"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -13,9 +16,24 @@ var historyCmd = &cobra.Command{
 	Short: "Dokumentänderungshistorie durchsuchen",
 	Long: `Änderungshistorie von Dokumenten durchsuchen.
 
+Jede Änderung wird mit Zeitpunkt, Art (neu, geändert, gelöscht) und
+Anwendung ausgegeben, nach Tagen gruppiert (neueste zuerst). Gelöschte
+Dokumente erscheinen nur mit --include-deleted und sind hervorgehoben.
+
+Die API meldet nur den Zeitpunkt der Änderung, nicht ihre Art. Als "neu"
+gilt ein Dokument, wenn es am selben Tag veröffentlicht wurde, an dem es
+geändert wurde (Veröffentlichungsdatum = Änderungsdatum), sonst als
+"geändert". Ein Dokument, das am Tag seiner Veröffentlichung noch
+korrigiert wurde, erscheint daher ebenfalls als "neu".
+
+Mit --ndjson wird jede Änderung sofort als eigene JSON-Zeile geschrieben,
+ohne Gruppierung; mit --all und --concurrency werden Seiten parallel
+abgerufen.
+
 Beispiele:
   risgo history --app bundesnormen --from 2024-01-01 --to 2024-01-31
-  risgo history --app justiz --from 2024-06-01 --include-deleted`,
+  risgo history --app justiz --from 2024-06-01 --include-deleted
+  risgo history --app bundesnormen --from 2024-01-01 --all --concurrency 4 --ndjson`,
 	RunE: runHistory,
 }
 
@@ -25,46 +43,107 @@ func init() {
 	f.String("from", "", "Änderungen von (JJJJ-MM-TT)")
 	f.String("to", "", "Änderungen bis (JJJJ-MM-TT)")
 	f.Bool("include-deleted", false, "Gelöschte Dokumente einschließen")
+	f.Bool("ndjson", false, "Jede Änderung als JSON-Objekt pro Zeile ausgeben, ungruppiert in der Reihenfolge der API")
 
 	rootCmd.AddCommand(historyCmd)
 }
 
 func runHistory(cmd *cobra.Command, args []string) error {
-	app, _ := cmd.Flags().GetString("app")
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	includeDeleted, _ := cmd.Flags().GetBool("include-deleted")
-
-	// --app is required.
-	if app == "" {
-		return errValidation("Fehler: --app ist erforderlich")
-	}
+	var q query.HistoryQuery
+	q.App, _ = cmd.Flags().GetString("app")
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.IncludeDeleted, _ = cmd.Flags().GetBool("include-deleted")
+	ndjson, _ := cmd.Flags().GetBool("ndjson")
 
-	// At least one of --from or --to required.
-	if from == "" && to == "" {
-		return errValidation("Fehler: mindestens --from oder --to erforderlich")
-	}
+	return executeHistory(cmd, q, ndjson)
+}
 
-	// Validate app value.
-	appLower := strings.ToLower(app)
-	if !constants.IsValidHistoryApp(appLower) {
-		return errValidation("Fehler: ungültiger --app Wert %q\nGültig: bundesnormen, landesnormen, justiz, vfgh, vwgh, bvwg, lvwg, bgblauth, bgblalt, bgblpdf, lgblauth, lgbl, lgblno, gemeinderecht, gemeinderechtauth, bvb, vbl, regv, mrp, erlaesse, pruefgewo, avsv, spg, kmger, dsk, gbk, dok, pvak, normenliste, asylgh", app)
+// executeHistory fetches the change events of q and writes them grouped by
+// day. With --all the events of all pages are collected first, up to
+// --max-results, since grouping needs the complete list; with --ndjson they
+// are written ungrouped as each page arrives. Pages that fail with --all
+// are reported and skipped, and the command then ends with an error.
+func executeHistory(cmd *cobra.Command, q query.HistoryQuery, ndjson bool) error {
+	if outputDir != "" {
+		return errValidation("Fehler: --output-dir wird von history nicht unterstützt")
+	}
+	params, err := q.Params()
+	if err != nil {
+		return queryError(err)
 	}
+	if err := setPageParams(cmd, params); err != nil {
+		return err
+	}
+	client, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
 
-	params := api.NewParams()
+	s := startSpinner(cmd, "Suche in Änderungshistorie...")
+	defer func() { stopSpinner(s) }()
 
-	// History uses Anwendung, NOT Applikation.
-	params.Set("Anwendung", appLower)
+	var (
+		result   model.HistoryResult
+		failures pageFailures
+	)
+	for page, err := range client.HistoryPagesConcurrent(commandContext(cmd), params, concurrency) {
+		var pageErr *api.PageError
+		if errors.As(err, &pageErr) {
+			failures.add(pageErr)
+			continue
+		}
+		if err != nil {
+			var apiErr *api.APIError
+			if errors.As(err, &apiErr) {
+				return err
+			}
+			return fmt.Errorf("API-Anfrage fehlgeschlagen: %w", err)
+		}
 
-	if from != "" {
-		params.Set("AenderungenVon", from)
+		events, capped := page.Events, false
+		if allPages && maxResults > 0 && len(result.Events)+len(events) >= maxResults {
+			events, capped = events[:maxResults-len(result.Events)], true
+		}
+		if ndjson {
+			stopSpinner(s)
+			s = nil
+			if err := format.NDJSONHistory(out, events); err != nil {
+				return err
+			}
+		}
+		if !allPages {
+			result = page
+			break
+		}
+
+		result.TotalHits = page.TotalHits
+		result.PageSize = page.PageSize
+		result.Events = append(result.Events, events...)
+		if capped {
+			result.HasMore = len(events) < len(page.Events) || page.HasMore
+			break
+		}
 	}
-	if to != "" {
-		params.Set("AenderungenBis", to)
+	stopSpinner(s)
+	s = nil
+
+	switch {
+	case ndjson:
+	case useJSON(cmd):
+		err = format.JSONHistory(out, result)
+	default:
+		err = format.TextHistory(out, result)
 	}
-	if includeDeleted {
-		params.Set("IncludeDeletedDocuments", "true")
+	if err != nil {
+		return err
 	}
-
-	return executeSearch(cmd, "History", "Suche in Änderungshistorie...", params)
+	if err := failures.err(); err != nil {
+		return err
+	}
+	if failEmpty && len(result.Events) == 0 {
+		return &EmptyResultError{}
+	}
+	return nil
 }
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/philrox/risgo/internal/model"
+	"github.com/philrox/risgo/pkg/ristest"
+)
+
+// runHistoryCommand runs the history command against the fake API server and
+// returns what it wrote.
+func runHistoryCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	srv, err := ristest.NewServer(ristest.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Close)
+	onlyContact(t, srv.Server)
+	t.Setenv("RIS_BASE_URL", srv.BaseURL())
+
+	// Flags keep their values between runs, so start from the defaults.
+	reset := func() {
+		rootCmd.SetOut(nil)
+		for _, name := range []string{"app", "from", "to"} {
+			historyCmd.Flags().Set(name, "")
+		}
+		historyCmd.Flags().Set("ndjson", "false")
+		historyCmd.Flags().Set("include-deleted", "false")
+		noCache, jsonOutput, allPages, concurrency = false, false, false, 1
+	}
+	reset()
+	t.Cleanup(reset)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	if err := executeCommand(append([]string{"history", "--no-cache"}, args...)...); err != nil {
+		t.Fatalf("history %v: %v", args, err)
+	}
+	return buf.String()
+}
+
+func TestHistory_NDJSON_AllPagesConcurrently(t *testing.T) {
+	out := runHistoryCommand(t, "--app", "bundesnormen", "--from", "2024-01-01", "--all", "--concurrency", "4", "--ndjson")
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	var numbers []string
+	for _, line := range lines {
+		var e model.HistoryEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", line, err)
+		}
+		numbers = append(numbers, e.Dokumentnummer)
+	}
+	if strings.Join(numbers, ",") != "NOR12017681,NOR12017682" {
+		t.Errorf("events = %v, want NOR12017681,NOR12017682 in API order", numbers)
+	}
+}
+
+func TestHistory_WritesToCommandOutput(t *testing.T) {
+	out := runHistoryCommand(t, "--app", "bundesnormen", "--from", "2024-01-01")
+	if !strings.Contains(out, "2024-07-01") || !strings.Contains(out, "NOR12017682") {
+		t.Errorf("output does not list the changes:\n%s", out)
+	}
+}
+
+func TestHistory_IncludeDeleted(t *testing.T) {
+	args := []string{"--app", "bundesnormen", "--from", "2024-01-01", "--json"}
+
+	var without struct {
+		TotalHits int `json:"total_hits"`
+	}
+	if err := json.Unmarshal([]byte(runHistoryCommand(t, args...)), &without); err != nil {
+		t.Fatal(err)
+	}
+	if without.TotalHits != 2 {
+		t.Errorf("without --include-deleted: %d changes, want 2", without.TotalHits)
+	}
+
+	var with struct {
+		Days []model.HistoryDay `json:"days"`
+	}
+	if err := json.Unmarshal([]byte(runHistoryCommand(t, append(args, "--include-deleted")...)), &with); err != nil {
+		t.Fatal(err)
+	}
+	var deleted []string
+	for _, day := range with.Days {
+		for _, e := range day.Events {
+			if e.Aenderung == model.ChangeDeleted {
+				deleted = append(deleted, e.Dokumentnummer)
+			}
+		}
+	}
+	if strings.Join(deleted, ",") != "NOR12017683" {
+		t.Errorf("deleted changes = %v, want NOR12017683", deleted)
+	}
+
+	// The text output highlights deletions and counts them.
+	text := runHistoryCommand(t, "--app", "bundesnormen", "--from", "2024-01-01", "--include-deleted")
+	if !strings.Contains(text, "GELÖSCHT") || !strings.Contains(text, "Davon gelöscht: 1") {
+		t.Errorf("text output does not flag the deletion:\n%s", text)
+	}
+}
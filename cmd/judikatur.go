@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"strings"
-
-	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -33,42 +30,13 @@ func init() {
 }
 
 func runJudikatur(cmd *cobra.Command, args []string) error {
-	search, _ := cmd.Flags().GetString("search")
-	norm, _ := cmd.Flags().GetString("norm")
-	caseNumber, _ := cmd.Flags().GetString("case-number")
-	court, _ := cmd.Flags().GetString("court")
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-
-	// At least one of search/norm/case-number required.
-	if search == "" && norm == "" && caseNumber == "" {
-		return errValidation("Fehler: mindestens --search, --norm oder --case-number erforderlich")
-	}
-
-	// Resolve court to Applikation value.
-	courtValue, ok := constants.Courts[strings.ToLower(court)]
-	if !ok {
-		return errValidation("Fehler: ungültiger --court Wert %q\nGültige Gerichte: justiz, vfgh, vwgh, bvwg, lvwg, dsk, asylgh, normenliste, pvak, gbk, dok", court)
-	}
-
-	params := api.NewParams()
-	params.Set("Applikation", courtValue)
-
-	if search != "" {
-		params.Set("Suchworte", search)
-	}
-	if norm != "" {
-		params.Set("Norm", norm)
-	}
-	if caseNumber != "" {
-		params.Set("Geschaeftszahl", caseNumber)
-	}
-	if from != "" {
-		params.Set("EntscheidungsdatumVon", from)
-	}
-	if to != "" {
-		params.Set("EntscheidungsdatumBis", to)
-	}
-
-	return executeSearch(cmd, "Judikatur", "Suche in Judikatur...", params)
+	var q query.JudikaturQuery
+	q.Search, _ = cmd.Flags().GetString("search")
+	q.Norm, _ = cmd.Flags().GetString("norm")
+	q.CaseNumber, _ = cmd.Flags().GetString("case-number")
+	q.Court, _ = cmd.Flags().GetString("court")
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+
+	return executeQuery(cmd, q, "Suche in Judikatur...")
 }
@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"strings"
-
-	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -29,32 +26,10 @@ func init() {
 }
 
 func runLandesrecht(cmd *cobra.Command, args []string) error {
-	search, _ := cmd.Flags().GetString("search")
-	title, _ := cmd.Flags().GetString("title")
-	state, _ := cmd.Flags().GetString("state")
-
-	// At least one required.
-	if search == "" && title == "" && state == "" {
-		return errValidation("Fehler: mindestens --search, --title oder --state erforderlich")
-	}
-
-	params := api.NewParams()
-	params.Set("Applikation", "LrKons")
-
-	if search != "" {
-		params.Set("Suchworte", search)
-	}
-	if title != "" {
-		params.Set("Titel", title)
-	}
-
-	if state != "" {
-		paramName, ok := constants.LandesrechtStates[strings.ToLower(state)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --state Wert %q\nGültige Bundesländer: wien, niederoesterreich, oberoesterreich, salzburg, tirol, vorarlberg, kaernten, steiermark, burgenland", state)
-		}
-		params.Set(paramName, "true")
-	}
+	var q query.LandesrechtQuery
+	q.Search, _ = cmd.Flags().GetString("search")
+	q.Title, _ = cmd.Flags().GetString("title")
+	q.State, _ = cmd.Flags().GetString("state")
 
-	return executeSearch(cmd, "Landesrecht", "Suche in Landesrecht...", params)
+	return executeQuery(cmd, q, "Suche in Landesrecht...")
 }
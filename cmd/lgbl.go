@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"strings"
-
-	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -32,45 +29,13 @@ func init() {
 }
 
 func runLgbl(cmd *cobra.Command, args []string) error {
-	number, _ := cmd.Flags().GetString("number")
-	year, _ := cmd.Flags().GetString("year")
-	state, _ := cmd.Flags().GetString("state")
-	search, _ := cmd.Flags().GetString("search")
-	title, _ := cmd.Flags().GetString("title")
-	app, _ := cmd.Flags().GetString("app")
-
-	// At least one required.
-	if number == "" && year == "" && state == "" && search == "" && title == "" {
-		return errValidation("Fehler: mindestens --number, --year, --state, --search oder --title erforderlich")
-	}
-
-	appValue, ok := constants.LgblApps[strings.ToLower(app)]
-	if !ok {
-		return errValidation("Fehler: ungültiger --app Wert %q (gültig: lgblauth, lgbl, lgblno)", app)
-	}
-
-	params := api.NewParams()
-	params.Set("Applikation", appValue)
-
-	if number != "" {
-		params.Set("Lgblnummer", number)
-	}
-	if year != "" {
-		params.Set("Jahrgang", year)
-	}
-	if state != "" {
-		paramName, ok := constants.LandesrechtStates[strings.ToLower(state)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --state Wert %q\nGültige Bundesländer: wien, niederoesterreich, oberoesterreich, salzburg, tirol, vorarlberg, kaernten, steiermark, burgenland", state)
-		}
-		params.Set(paramName, "true")
-	}
-	if search != "" {
-		params.Set("Suchworte", search)
-	}
-	if title != "" {
-		params.Set("Titel", title)
-	}
-
-	return executeSearch(cmd, "Landesrecht", "Suche in Landesgesetzblättern...", params)
+	var q query.LgblQuery
+	q.Number, _ = cmd.Flags().GetString("number")
+	q.Year, _ = cmd.Flags().GetString("year")
+	q.State, _ = cmd.Flags().GetString("state")
+	q.Search, _ = cmd.Flags().GetString("search")
+	q.Title, _ = cmd.Flags().GetString("title")
+	q.App, _ = cmd.Flags().GetString("app")
+
+	return executeQuery(cmd, q, "Suche in Landesgesetzblättern...")
 }
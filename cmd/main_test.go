@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain points the response cache at a throwaway directory so tests
+// neither read from nor write to the user's real cache, and disables
+// retries so failing requests do not wait for backoff.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "risgo-cmd-test-")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("RIS_CACHE_DIR", dir)
+	retries = 0
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/format"
+	"github.com/philrox/risgo/internal/model"
+	"github.com/spf13/cobra"
+)
+
+// manifestName is the file --output-dir lists the saved documents in.
+const manifestName = "manifest.json"
+
+// Status values of a manifest entry.
+const (
+	fileWritten = "written"
+	fileSkipped = "skipped"
+	fileFailed  = "failed"
+)
+
+// manifest describes a run with --output-dir.
+type manifest struct {
+	CreatedAt time.Time   `json:"created_at"`
+	Directory string      `json:"directory"`
+	Format    string      `json:"format"`
+	Template  string      `json:"template"`
+	Files     []savedFile `json:"files"`
+}
+
+// savedFile is the manifest entry of one document.
+type savedFile struct {
+	Dokumentnummer string     `json:"dokumentnummer"`
+	File           string     `json:"file,omitempty"`
+	Status         string     `json:"status"`
+	Bytes          int64      `json:"bytes,omitempty"`
+	URL            string     `json:"url,omitempty"`
+	Error          *errorInfo `json:"error,omitempty"`
+
+	err error
+}
+
+// saveJob is a document to save: a search hit whose metadata is known, or
+// a document number whose metadata is looked up first.
+type saveJob struct {
+	number string
+	doc    *model.Document
+}
+
+// fileSaver writes documents to --output-dir.
+type fileSaver struct {
+	dir       string
+	format    documentFormat
+	template  string
+	names     *format.FileNameTemplate
+	overwrite bool
+	claimed   map[string]bool // file names used in this run
+}
+
+// newFileSaver validates --name-template and creates the output directory.
+func newFileSaver(docFormat documentFormat) (*fileSaver, error) {
+	tmplText := nameTemplate
+	if tmplText == "" {
+		ext := docFormat.name
+		if !docFormat.raw() {
+			ext = "txt"
+		}
+		tmplText = "{{.Dokumentnummer}}." + ext
+	}
+	names, err := format.ParseFileNameTemplate(tmplText)
+	if err != nil {
+		return nil, errValidation("Fehler: ungültige --name-template: %v", err)
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("Fehler: Ausgabeverzeichnis konnte nicht angelegt werden: %w", err)
+	}
+	// Text files never get ANSI colors, whatever stdout is.
+	color.NoColor = true
+	return &fileSaver{
+		dir:       outputDir,
+		format:    docFormat,
+		template:  tmplText,
+		names:     names,
+		overwrite: ifExists == "overwrite",
+		claimed:   map[string]bool{manifestName: true},
+	}, nil
+}
+
+// claim reserves name for this run. If another document of the run already
+// uses it, the document number is appended ("name_NOR12017681.txt"), and
+// only if that is taken too a counter ("name_2.txt").
+func (s *fileSaver) claim(name, number string) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if s.claimed[name] && number != "" {
+		name = base + "_" + format.SanitizeFileName(number) + ext
+		base = strings.TrimSuffix(name, ext)
+	}
+	for i := 2; s.claimed[name]; i++ {
+		name = base + "_" + strconv.Itoa(i) + ext
+	}
+	s.claimed[name] = true
+	return name
+}
+
+// resolve returns the metadata of job's document, looking it up by number
+// if the job has none yet.
+func (s *fileSaver) resolve(ctx context.Context, client *api.Client, job saveJob) (model.Document, error) {
+	if job.doc != nil {
+		return *job.doc, nil
+	}
+	if err := validateDocNumber(job.number); err != nil {
+		return model.Document{}, errValidation("Fehler: %v", err)
+	}
+	return documentMetadata(func() (model.Document, error) {
+		return lookupDocument(ctx, client, job.number)
+	}, job.number, "")
+}
+
+// plan resolves the metadata of every job (up to --concurrency lookups at a
+// time) and then claims the file names serially in job order, so the names
+// do not depend on which lookup finishes first. Jobs that fail are returned
+// as failed manifest entries.
+func (s *fileSaver) plan(ctx context.Context, client *api.Client, jobs []saveJob, workers int) ([]plannedFile, error) {
+	type resolved struct {
+		doc model.Document
+		err error
+	}
+	results := fetchOrdered(ctx, len(jobs), workers, func(i int) resolved {
+		doc, err := s.resolve(ctx, client, jobs[i])
+		return resolved{doc, err}
+	})
+
+	planned := make([]plannedFile, len(jobs))
+	for i, job := range jobs {
+		r, err := results.next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if errors.Is(r.err, context.Canceled) {
+			return nil, r.err
+		}
+		p := &planned[i]
+		p.entry.Dokumentnummer = job.number
+		if r.err != nil {
+			p.fail(r.err)
+			continue
+		}
+		p.doc = r.doc
+		p.entry.Dokumentnummer = r.doc.Dokumentnummer
+		name, err := s.names.Name(r.doc)
+		if err != nil {
+			p.fail(fmt.Errorf("Fehler: Dateiname konnte nicht erzeugt werden: %w", err))
+			continue
+		}
+		p.entry.File = s.claim(name, r.doc.Dokumentnummer)
+	}
+	return planned, nil
+}
+
+// plannedFile is a document with its claimed file name, or the manifest
+// entry of a document that failed before a name could be claimed.
+type plannedFile struct {
+	doc   model.Document
+	entry savedFile
+}
+
+// fail marks the entry as failed with err.
+func (p *plannedFile) fail(err error) {
+	p.entry.Status, p.entry.err = fileFailed, err
+	info := classifyError(err)
+	p.entry.Error = &info
+}
+
+// save fetches a planned document and writes it to its file.
+func (s *fileSaver) save(ctx context.Context, client *api.Client, p plannedFile) savedFile {
+	if p.entry.Status == fileFailed {
+		return p.entry
+	}
+	fail := func(err error) savedFile {
+		p.fail(err)
+		return p.entry
+	}
+	doc, entry := p.doc, &p.entry
+
+	path := filepath.Join(s.dir, entry.File)
+	if !s.overwrite {
+		if _, err := os.Lstat(path); err == nil {
+			entry.Status = fileSkipped
+			return *entry
+		}
+	}
+
+	body, docURL, err := s.open(ctx, client, doc)
+	if err != nil {
+		return fail(err)
+	}
+	entry.URL = docURL
+	if body != nil {
+		defer body.Close()
+	}
+	if doc.DokumentURL == "" {
+		doc.DokumentURL = docURL
+	}
+
+	err = writeFileAtomic(path, func(w io.Writer) error {
+		cw := &countingWriter{w: w}
+		var err error
+		switch {
+		case s.format.raw():
+			_, err = io.Copy(cw, body)
+		case body == nil:
+			err = format.TextDocument(cw, doc, "")
+		default:
+			err = format.TextDocumentHTML(cw, doc, body)
+		}
+		entry.Bytes = cw.n
+		if err != nil {
+			return fmt.Errorf("Dokument konnte nicht gelesen werden: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fail(err)
+	}
+	entry.Status = fileWritten
+	return *entry
+}
+
+// open opens the requested rendition of doc: from the content URL in its
+// metadata or, failing that and only against the public RIS API, the URL
+// derived from its number. If all fail, the error of the first candidate is
+// reported, since the fallbacks are only guesses. A text document without
+// content yields a nil body (metadata only).
+func (s *fileSaver) open(ctx context.Context, client *api.Client, doc model.Document) (io.ReadCloser, string, error) {
+	dataType := s.format.dataType
+	urls := []string{doc.ContentURLs.ForType(dataType)}
+	if dataType == "html" {
+		urls = append(urls, doc.DokumentURL)
+	}
+	if client.PublicAPI() {
+		urls = append(urls, model.DirectURLForType(doc.Dokumentnummer, dataType))
+	}
+
+	var firstErr error
+	for _, docURL := range urls {
+		if docURL == "" {
+			continue
+		}
+		body, err := client.OpenDocument(ctx, docURL, s.format.mediaTypes...)
+		if err == nil {
+			return body, docURL, nil
+		}
+		if errors.Is(err, context.Canceled) {
+			return nil, "", err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return nil, "", fmt.Errorf("Dokument konnte nicht abgerufen werden: %w", firstErr)
+	}
+	if s.format.raw() {
+		return nil, "", errNotFound("Fehler: Dokument %q ist nicht als %s verfügbar", doc.Dokumentnummer, strings.ToUpper(s.format.name))
+	}
+	return nil, "", nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// saveDocuments writes the documents of jobs to --output-dir, up to
+// --concurrency at a time, and lists them in manifest.json. Progress is
+// reported on stdout (the manifest itself with --json). Documents that fail
+// do not abort the run; the command then ends with an error naming the
+// first failure.
+func saveDocuments(cmd *cobra.Command, client *api.Client, saver *fileSaver, jobs []saveJob) error {
+	ctx, cancel := context.WithCancel(commandContext(cmd))
+	defer cancel()
+
+	s := startSpinner(cmd, fmt.Sprintf("Speichere %d Dokumente...", len(jobs)))
+	defer func() { stopSpinner(s) }()
+
+	workers := documentConcurrency(cmd)
+	planned, err := saver.plan(ctx, client, jobs, workers)
+	if err != nil {
+		return err
+	}
+	results := fetchOrdered(ctx, len(planned), workers, func(i int) savedFile {
+		return saver.save(ctx, client, planned[i])
+	})
+
+	m := manifest{
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Directory: saver.dir,
+		Format:    saver.format.name,
+		Template:  saver.template,
+		Files:     make([]savedFile, 0, len(jobs)),
+	}
+	var (
+		counts    = map[string]int{}
+		firstFail error
+	)
+	for range jobs {
+		entry, err := results.next(ctx)
+		if err != nil {
+			return err
+		}
+		stopSpinner(s)
+		s = nil
+
+		if errors.Is(entry.err, context.Canceled) {
+			return entry.err
+		}
+		m.Files = append(m.Files, entry)
+		counts[entry.Status]++
+		if entry.err != nil && firstFail == nil {
+			firstFail = entry.err
+		}
+		if !useJSON(cmd) && !quiet {
+			switch entry.Status {
+			case fileWritten:
+				fmt.Printf("gespeichert     %s\n", filepath.Join(saver.dir, entry.File))
+			case fileSkipped:
+				fmt.Printf("übersprungen    %s (vorhanden)\n", filepath.Join(saver.dir, entry.File))
+			case fileFailed:
+				fmt.Printf("fehlgeschlagen  %s: %s\n", cmp.Or(entry.Dokumentnummer, entry.File), entry.Error.Message)
+			}
+		}
+	}
+
+	manifestPath := filepath.Join(saver.dir, manifestName)
+	err = writeFileAtomic(manifestPath, func(w io.Writer) error { return writeManifest(w, m) })
+	if err != nil {
+		return err
+	}
+	if useJSON(cmd) {
+		if err := writeManifest(os.Stdout, m); err != nil {
+			return err
+		}
+	} else if !quiet {
+		fmt.Printf("\n%d gespeichert, %d übersprungen, %d fehlgeschlagen. Manifest: %s\n",
+			counts[fileWritten], counts[fileSkipped], counts[fileFailed], manifestPath)
+	}
+
+	if firstFail != nil {
+		return errWrap(firstFail, "Fehler: %d von %d Dokumenten konnten nicht gespeichert werden", counts[fileFailed], len(jobs))
+	}
+	return nil
+}
+
+func writeManifest(w io.Writer, m manifest) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// executeSearchToDir runs a search (all pages with --all) and saves the
+// content of every hit to --output-dir.
+func executeSearchToDir(cmd *cobra.Command, endpoint, spinnerMsg string, params *api.Params) error {
+	saver, err := newFileSaver(documentFormats[0]) // text
+	if err != nil {
+		return err
+	}
+	client, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	s := startSpinner(cmd, spinnerMsg)
+	docs, failures, err := collectSearchDocuments(cmd, client, endpoint, params)
+	stopSpinner(s)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		if err := failures.err(); err != nil {
+			return err
+		}
+		if failEmpty {
+			return &EmptyResultError{}
+		}
+		if !quiet {
+			fmt.Fprintln(os.Stderr, "Keine Ergebnisse gefunden.")
+		}
+		return nil
+	}
+
+	jobs := make([]saveJob, len(docs))
+	for i := range docs {
+		jobs[i] = saveJob{number: docs[i].Dokumentnummer, doc: &docs[i]}
+	}
+	// The manifest is written either way; missing pages fail the run too.
+	return errors.Join(saveDocuments(cmd, client, saver, jobs), failures.err())
+}
+
+// collectSearchDocuments returns the hits of a search: the requested page,
+// or with --all every page up to --max-results. Pages that fail with --all
+// are reported and skipped; they are returned as pageFailures so the caller
+// can end with an error once the other documents are saved.
+func collectSearchDocuments(cmd *cobra.Command, client *api.Client, endpoint string, params *api.Params) ([]model.Document, *pageFailures, error) {
+	ctx := commandContext(cmd)
+	failures := &pageFailures{}
+	if !allPages {
+		result, err := searchPage(ctx, client, endpoint, params)
+		return result.Documents, failures, err
+	}
+
+	var docs []model.Document
+	for result, err := range client.SearchPagesConcurrent(ctx, endpoint, params, concurrency) {
+		var pageErr *api.PageError
+		if errors.As(err, &pageErr) {
+			failures.add(pageErr)
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("API-Anfrage fehlgeschlagen: %w", err)
+		}
+		docs = append(docs, result.Documents...)
+		if maxResults > 0 && len(docs) >= maxResults {
+			return docs[:maxResults], failures, nil
+		}
+	}
+	return docs, failures, nil
+}
@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"strings"
-
-	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -164,328 +161,105 @@ func init() {
 	rootCmd.AddCommand(sonstigeCmd)
 }
 
-// setCommonSonstigeParams sets the common parameters shared by all sonstige sub-commands.
-func setCommonSonstigeParams(cmd *cobra.Command, params *api.Params) error {
-	search, _ := cmd.Flags().GetString("search")
-	title, _ := cmd.Flags().GetString("title")
-	since, _ := cmd.Flags().GetString("since")
-	sortDir, _ := cmd.Flags().GetString("sort-dir")
-
-	if search != "" {
-		params.Set("Suchworte", search)
-	}
-	if title != "" {
-		params.Set("Titel", title)
-	}
-	if since != "" {
-		value, ok := constants.ImRisSeit[strings.ToLower(since)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --since Wert %q", since)
-		}
-		params.Set("ImRisSeit", value)
-	}
-	if sortDir != "" {
-		value, ok := constants.SortDirections[strings.ToLower(sortDir)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --sort-dir Wert %q (gültig: asc, desc)", sortDir)
-		}
-		params.Set("Sortierung.SortDirection", value)
-	}
-	return nil
+// sonstigeFlags reads the flags shared by all sonstige sub-commands.
+func sonstigeFlags(cmd *cobra.Command) query.Sonstige {
+	var common query.Sonstige
+	common.Search, _ = cmd.Flags().GetString("search")
+	common.Title, _ = cmd.Flags().GetString("title")
+	common.Since, _ = cmd.Flags().GetString("since")
+	common.SortDir, _ = cmd.Flags().GetString("sort-dir")
+	return common
 }
 
-func executeSonstigeSearch(cmd *cobra.Command, params *api.Params) error {
-	return executeSearch(cmd, "Sonstige", "Suche in Sonstige Rechtsquellen...", params)
+func executeSonstigeSearch(cmd *cobra.Command, q query.Query) error {
+	return executeQuery(cmd, q, "Suche in Sonstige Rechtsquellen...")
 }
 
 func runMrp(cmd *cobra.Command, args []string) error {
-	params := api.NewParams()
-	params.Set("Applikation", "Mrp")
-	if err := setCommonSonstigeParams(cmd, params); err != nil {
-		return err
-	}
-
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	submitter, _ := cmd.Flags().GetString("submitter")
-	session, _ := cmd.Flags().GetString("session")
-	period, _ := cmd.Flags().GetString("period")
-	fileNumber, _ := cmd.Flags().GetString("file-number")
-
-	if from != "" {
-		params.Set("Sitzungsdatum.Von", from)
-	}
-	if to != "" {
-		params.Set("Sitzungsdatum.Bis", to)
-	}
-	if submitter != "" {
-		params.Set("Einbringer", submitter)
-	}
-	if session != "" {
-		params.Set("Sitzungsnummer", session)
-	}
-	if period != "" {
-		params.Set("Gesetzgebungsperiode", period)
-	}
-	if fileNumber != "" {
-		params.Set("Geschaeftszahl", fileNumber)
-	}
-
-	return executeSonstigeSearch(cmd, params)
+	q := query.MrpQuery{Sonstige: sonstigeFlags(cmd)}
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.Submitter, _ = cmd.Flags().GetString("submitter")
+	q.Session, _ = cmd.Flags().GetString("session")
+	q.Period, _ = cmd.Flags().GetString("period")
+	q.FileNumber, _ = cmd.Flags().GetString("file-number")
+
+	return executeSonstigeSearch(cmd, q)
 }
 
 func runErlaesse(cmd *cobra.Command, args []string) error {
-	params := api.NewParams()
-	params.Set("Applikation", "Erlaesse")
-	if err := setCommonSonstigeParams(cmd, params); err != nil {
-		return err
-	}
-
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	ministry, _ := cmd.Flags().GetString("ministry")
-	department, _ := cmd.Flags().GetString("department")
-	source, _ := cmd.Flags().GetString("source")
-	norm, _ := cmd.Flags().GetString("norm")
-	date, _ := cmd.Flags().GetString("date")
-
-	if from != "" {
-		params.Set("VonInkrafttretensdatum", from)
-	}
-	if to != "" {
-		params.Set("BisInkrafttretensdatum", to)
-	}
-	if ministry != "" {
-		value, ok := constants.ErlMinistries[strings.ToLower(ministry)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --ministry Wert %q\nGültig: bka, bmkoes, bmeia, bmaw, bmbwf, bmf, bmi, bmj, bmk, bmlv, bml, bmsgpk", ministry)
-		}
-		params.Set("Bundesministerium", value)
-	}
-	if department != "" {
-		params.Set("Abteilung", department)
-	}
-	if source != "" {
-		params.Set("Fundstelle", source)
-	}
-	if norm != "" {
-		params.Set("Norm", norm)
-	}
-	if date != "" {
-		params.Set("FassungVom", date)
-	}
-
-	return executeSonstigeSearch(cmd, params)
+	q := query.ErlaesseQuery{Sonstige: sonstigeFlags(cmd)}
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.Ministry, _ = cmd.Flags().GetString("ministry")
+	q.Department, _ = cmd.Flags().GetString("department")
+	q.Source, _ = cmd.Flags().GetString("source")
+	q.Norm, _ = cmd.Flags().GetString("norm")
+	q.Date, _ = cmd.Flags().GetString("date")
+
+	return executeSonstigeSearch(cmd, q)
 }
 
 func runUpts(cmd *cobra.Command, args []string) error {
-	params := api.NewParams()
-	params.Set("Applikation", "Upts")
-	if err := setCommonSonstigeParams(cmd, params); err != nil {
-		return err
-	}
-
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	party, _ := cmd.Flags().GetString("party")
-	fileNumber, _ := cmd.Flags().GetString("file-number")
-	norm, _ := cmd.Flags().GetString("norm")
-
-	if from != "" {
-		params.Set("Entscheidungsdatum.Von", from)
-	}
-	if to != "" {
-		params.Set("Entscheidungsdatum.Bis", to)
-	}
-	if party != "" {
-		value, ok := constants.UptsParties[strings.ToLower(party)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --party Wert %q\nGültig: spoe, oevp, fpoe, gruene, neos, bzoe", party)
-		}
-		params.Set("Partei", value)
-	}
-	if fileNumber != "" {
-		params.Set("Geschaeftszahl", fileNumber)
-	}
-	if norm != "" {
-		params.Set("Norm", norm)
-	}
-
-	return executeSonstigeSearch(cmd, params)
+	q := query.UptsQuery{Sonstige: sonstigeFlags(cmd)}
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.Party, _ = cmd.Flags().GetString("party")
+	q.FileNumber, _ = cmd.Flags().GetString("file-number")
+	q.Norm, _ = cmd.Flags().GetString("norm")
+
+	return executeSonstigeSearch(cmd, q)
 }
 
 func runKmger(cmd *cobra.Command, args []string) error {
-	params := api.NewParams()
-	params.Set("Applikation", "KmGer")
-	if err := setCommonSonstigeParams(cmd, params); err != nil {
-		return err
-	}
-
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	typ, _ := cmd.Flags().GetString("type")
-	courtName, _ := cmd.Flags().GetString("court-name")
-	fileNumber, _ := cmd.Flags().GetString("file-number")
-
-	if from != "" {
-		params.Set("Kundmachungsdatum.Von", from)
-	}
-	if to != "" {
-		params.Set("Kundmachungsdatum.Bis", to)
-	}
-	if typ != "" {
-		value, ok := constants.KmgerTypes[strings.ToLower(typ)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --type Wert %q (gültig: geschaeftsordnung, geschaeftsverteilung)", typ)
-		}
-		params.Set("Typ", value)
-	}
-	if courtName != "" {
-		params.Set("Gericht", courtName)
-	}
-	if fileNumber != "" {
-		params.Set("Geschaeftszahl", fileNumber)
-	}
-
-	return executeSonstigeSearch(cmd, params)
+	q := query.KmgerQuery{Sonstige: sonstigeFlags(cmd)}
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.Type, _ = cmd.Flags().GetString("type")
+	q.CourtName, _ = cmd.Flags().GetString("court-name")
+	q.FileNumber, _ = cmd.Flags().GetString("file-number")
+
+	return executeSonstigeSearch(cmd, q)
 }
 
 func runAvsv(cmd *cobra.Command, args []string) error {
-	params := api.NewParams()
-	params.Set("Applikation", "Avsv")
-	if err := setCommonSonstigeParams(cmd, params); err != nil {
-		return err
-	}
-
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	docType, _ := cmd.Flags().GetString("doc-type")
-	author, _ := cmd.Flags().GetString("author")
-	avsvNumber, _ := cmd.Flags().GetString("avsv-number")
-
-	if from != "" {
-		params.Set("Kundmachung.Von", from)
-	}
-	if to != "" {
-		params.Set("Kundmachung.Bis", to)
-	}
-	if docType != "" {
-		params.Set("Dokumentart", docType)
-	}
-	if author != "" {
-		value, ok := constants.AvsvAuthors[strings.ToLower(author)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --author Wert %q\nGültig: dvsv, pva, oegk, auva, svs, bvaeb", author)
-		}
-		params.Set("Urheber", value)
-	}
-	if avsvNumber != "" {
-		params.Set("Avsvnummer", avsvNumber)
-	}
-
-	return executeSonstigeSearch(cmd, params)
+	q := query.AvsvQuery{Sonstige: sonstigeFlags(cmd)}
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.DocType, _ = cmd.Flags().GetString("doc-type")
+	q.Author, _ = cmd.Flags().GetString("author")
+	q.AvsvNumber, _ = cmd.Flags().GetString("avsv-number")
+
+	return executeSonstigeSearch(cmd, q)
 }
 
 func runAvn(cmd *cobra.Command, args []string) error {
-	params := api.NewParams()
-	params.Set("Applikation", "Avn")
-	if err := setCommonSonstigeParams(cmd, params); err != nil {
-		return err
-	}
-
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	avnNumber, _ := cmd.Flags().GetString("avn-number")
-	typ, _ := cmd.Flags().GetString("type")
-
-	if from != "" {
-		params.Set("Kundmachung.Von", from)
-	}
-	if to != "" {
-		params.Set("Kundmachung.Bis", to)
-	}
-	if avnNumber != "" {
-		params.Set("Avnnummer", avnNumber)
-	}
-	if typ != "" {
-		value, ok := constants.AvnTypes[strings.ToLower(typ)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --type Wert %q (gültig: kundmachung, verordnung, erlass)", typ)
-		}
-		params.Set("Typ", value)
-	}
+	q := query.AvnQuery{Sonstige: sonstigeFlags(cmd)}
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.AvnNumber, _ = cmd.Flags().GetString("avn-number")
+	q.Type, _ = cmd.Flags().GetString("type")
 
-	return executeSonstigeSearch(cmd, params)
+	return executeSonstigeSearch(cmd, q)
 }
 
 func runSpg(cmd *cobra.Command, args []string) error {
-	params := api.NewParams()
-	params.Set("Applikation", "Spg")
-	if err := setCommonSonstigeParams(cmd, params); err != nil {
-		return err
-	}
-
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	spgNumber, _ := cmd.Flags().GetString("spg-number")
-	osgType, _ := cmd.Flags().GetString("osg-type")
-	rsgType, _ := cmd.Flags().GetString("rsg-type")
-	rsgState, _ := cmd.Flags().GetString("rsg-state")
-
-	if from != "" {
-		params.Set("Kundmachungsdatum.Von", from)
-	}
-	if to != "" {
-		params.Set("Kundmachungsdatum.Bis", to)
-	}
-	if spgNumber != "" {
-		params.Set("Spgnummer", spgNumber)
-	}
-	if osgType != "" {
-		value, ok := constants.OsgTypes[strings.ToLower(osgType)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --osg-type Wert %q (gültig: oesg, oesg-grossgeraete)", osgType)
-		}
-		params.Set("OsgTyp", value)
-	}
-	if rsgType != "" {
-		value, ok := constants.RsgTypes[strings.ToLower(rsgType)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --rsg-type Wert %q (gültig: rsg, rsg-grossgeraete)", rsgType)
-		}
-		params.Set("RsgTyp", value)
-	}
-	if rsgState != "" {
-		params.Set("RsgLand", rsgState)
-	}
-
-	return executeSonstigeSearch(cmd, params)
+	q := query.SpgQuery{Sonstige: sonstigeFlags(cmd)}
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.SpgNumber, _ = cmd.Flags().GetString("spg-number")
+	q.OsgType, _ = cmd.Flags().GetString("osg-type")
+	q.RsgType, _ = cmd.Flags().GetString("rsg-type")
+	q.RsgState, _ = cmd.Flags().GetString("rsg-state")
+
+	return executeSonstigeSearch(cmd, q)
 }
 
 func runPruefgewo(cmd *cobra.Command, args []string) error {
-	params := api.NewParams()
-	params.Set("Applikation", "PruefGewO")
-	if err := setCommonSonstigeParams(cmd, params); err != nil {
-		return err
-	}
-
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-	typ, _ := cmd.Flags().GetString("type")
-
-	if from != "" {
-		params.Set("Kundmachungsdatum.Von", from)
-	}
-	if to != "" {
-		params.Set("Kundmachungsdatum.Bis", to)
-	}
-	if typ != "" {
-		value, ok := constants.PruefgewoTypes[strings.ToLower(typ)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --type Wert %q (gültig: befaehigung, eignung, meister)", typ)
-		}
-		params.Set("Typ", value)
-	}
+	q := query.PruefGewOQuery{Sonstige: sonstigeFlags(cmd)}
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+	q.Type, _ = cmd.Flags().GetString("type")
 
-	return executeSonstigeSearch(cmd, params)
+	return executeSonstigeSearch(cmd, q)
 }
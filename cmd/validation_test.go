@@ -2,7 +2,11 @@ package cmd
 
 import (
 	"errors"
+	"strings"
 	"testing"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/spf13/pflag"
 )
 
 // executeCommand runs a cobra command with the given args and returns the error.
@@ -41,7 +45,7 @@ func findSubstr(s, substr string) bool {
 
 func TestBundesrecht_NoArgs_ReturnsValidationError(t *testing.T) {
 	err := executeCommand("bundesrecht")
-	assertValidationError(t, err, "mindestens --search, --title oder --paragraph erforderlich")
+	assertValidationError(t, err, "mindestens ein Suchparameter erforderlich (--search, --title, --paragraph, --law-number, --index, --keywords)")
 }
 
 func TestBundesrecht_InvalidApp_ReturnsValidationError(t *testing.T) {
@@ -49,6 +53,15 @@ func TestBundesrecht_InvalidApp_ReturnsValidationError(t *testing.T) {
 	assertValidationError(t, err, "ungültiger --app Wert")
 }
 
+func TestBundesrecht_TypeWithOtherApp_ReturnsValidationError(t *testing.T) {
+	defer func() {
+		bundesrechtCmd.Flags().Set("app", "brkons")
+		bundesrechtCmd.Flags().Set("type", "")
+	}()
+	err := executeCommand("bundesrecht", "--search", "test", "--app", "begut", "--type", "BG")
+	assertValidationError(t, err, "--type nur mit --app brkons möglich")
+}
+
 func TestLandesrecht_NoArgs_ReturnsValidationError(t *testing.T) {
 	err := executeCommand("landesrecht")
 	assertValidationError(t, err, "mindestens --search, --title oder --state erforderlich")
@@ -99,11 +112,22 @@ func TestHistory_NoDateRange_ReturnsValidationError(t *testing.T) {
 	assertValidationError(t, err, "mindestens --from oder --to erforderlich")
 }
 
+func TestHistory_OutputDir_ReturnsValidationError(t *testing.T) {
+	defer func() { outputDir = "" }()
+	err := executeCommand("history", "--app", "bundesnormen", "--from", "2024-01-01", "--output-dir", t.TempDir())
+	assertValidationError(t, err, "--output-dir wird von history nicht unterstützt")
+}
+
 func TestHistory_InvalidApp_ReturnsValidationError(t *testing.T) {
 	err := executeCommand("history", "--app", "invalid", "--from", "2024-01-01")
 	assertValidationError(t, err, "ungültiger --app Wert")
 }
 
+func TestJudikatur_InvalidDate_ReturnsValidationError(t *testing.T) {
+	err := executeCommand("judikatur", "--search", "test", "--court", "justiz", "--from", "15.01.2024")
+	assertValidationError(t, err, "ungültiger --from Wert")
+}
+
 func TestDokument_NoArgs_ReturnsValidationError(t *testing.T) {
 	err := executeCommand("dokument")
 	assertValidationError(t, err, "Dokumentnummer oder --url erforderlich")
@@ -149,3 +173,77 @@ func TestSonstige_InvalidSortDir_ReturnsValidationError(t *testing.T) {
 	err := executeCommand("sonstige", "erlaesse", "--search", "test", "--sort-dir", "invalid")
 	assertValidationError(t, err, "ungültiger --sort-dir Wert")
 }
+
+func TestConcurrency_RequiresAll_ReturnsValidationError(t *testing.T) {
+	defer func() { concurrency = 1 }()
+	err := executeCommand("bundesrecht", "--search", "test", "--concurrency", "4")
+	assertValidationError(t, err, "--concurrency erfordert --all")
+}
+
+func TestConcurrency_OutOfRange_ReturnsValidationError(t *testing.T) {
+	defer func() { concurrency, allPages = 1, false }()
+	err := executeCommand("bundesrecht", "--search", "test", "--all", "--concurrency", "99")
+	assertValidationError(t, err, "--concurrency muss zwischen")
+}
+
+func TestTLSMin_Invalid_ReturnsValidationError(t *testing.T) {
+	defer func() { tlsMinVersion = "" }()
+	err := executeCommand("bundesrecht", "--search", "test", "--tls-min", "1.0")
+	assertValidationError(t, err, "ungültiger --tls-min Wert")
+}
+
+func TestCACert_Missing_ReturnsValidationError(t *testing.T) {
+	defer func() { caFiles = nil }()
+	err := executeCommand("bundesrecht", "--search", "test", "--app", "brkons", "--ca-cert", "/nonexistent/ca.pem")
+	assertValidationError(t, err, "CA-Zertifikat nicht lesbar")
+}
+
+func TestAllowHost_Invalid_ReturnsValidationError(t *testing.T) {
+	defer func() { allowHosts = nil }()
+	err := executeCommand("bundesrecht", "--search", "test", "--app", "brkons", "--allow-host", "*.example.com")
+	assertValidationError(t, err, "ungültiger erlaubter Host")
+}
+
+func TestApplyEnv_IgnoresInvalidValues(t *testing.T) {
+	flags := rootCmd.PersistentFlags()
+	// Earlier tests may have passed the flags on the command line.
+	flags.Lookup("retries").Changed = false
+	flags.Lookup("allow-host").Changed = false
+	savedRetries := retries
+	defer func() {
+		retries = savedRetries
+		flags.Lookup("allow-host").Value.(pflag.SliceValue).Replace(nil)
+	}()
+
+	// A negative RIS_RETRIES used to fail every command; it is ignored.
+	t.Setenv("RIS_RETRIES", "-1")
+	if err := executeCommand("version"); err != nil {
+		t.Fatalf("version with RIS_RETRIES=-1: %v", err)
+	}
+	if retries != savedRetries {
+		t.Errorf("retries = %d after invalid RIS_RETRIES, want %d", retries, savedRetries)
+	}
+
+	t.Setenv("RIS_RETRIES", "3")
+	applyEnv("RIS_RETRIES", "retries", func() bool { return retries >= 0 })
+	if retries != 3 || flags.Changed("retries") {
+		t.Errorf("retries = %d (changed %v), want 3 from the environment", retries, flags.Changed("retries"))
+	}
+
+	// Applying a list twice must not duplicate its entries.
+	t.Setenv("RIS_ALLOWED_HOSTS", "a.example, b.example,")
+	applyEnv("RIS_ALLOWED_HOSTS", "allow-host", nil)
+	applyEnv("RIS_ALLOWED_HOSTS", "allow-host", nil)
+	if got := strings.Join(allowHosts, ","); got != "a.example,b.example" {
+		t.Errorf("allowHosts = %q, want a.example,b.example", got)
+	}
+}
+
+func TestReplayMatch_IgnoresInvalidValue(t *testing.T) {
+	for value, want := range map[string]api.ReplayMatch{"": "", "Lenient": api.MatchLenient, "lenent": api.MatchStrict} {
+		t.Setenv("RIS_REPLAY_MATCH", value)
+		if got := replayMatch(); got != want {
+			t.Errorf("RIS_REPLAY_MATCH=%q: replayMatch() = %q, want %q", value, got, want)
+		}
+	}
+}
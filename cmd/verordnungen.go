@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"strings"
-
-	"github.com/philrox/risgo/internal/api"
-	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/query"
 	"github.com/spf13/cobra"
 )
 
@@ -32,44 +29,13 @@ func init() {
 }
 
 func runVerordnungen(cmd *cobra.Command, args []string) error {
-	search, _ := cmd.Flags().GetString("search")
-	title, _ := cmd.Flags().GetString("title")
-	state, _ := cmd.Flags().GetString("state")
-	number, _ := cmd.Flags().GetString("number")
-	from, _ := cmd.Flags().GetString("from")
-	to, _ := cmd.Flags().GetString("to")
-
-	// At least one required.
-	if search == "" && title == "" && state == "" && number == "" && from == "" {
-		return errValidation("Fehler: mindestens --search, --title, --state, --number oder --from erforderlich")
-	}
-
-	params := api.NewParams()
-	params.Set("Applikation", "Vbl")
-
-	if search != "" {
-		params.Set("Suchworte", search)
-	}
-	if title != "" {
-		params.Set("Titel", title)
-	}
-	if state != "" {
-		// Verordnungen uses direct Bundesland values, NOT SucheIn* format.
-		value, ok := constants.VerordnungenStates[strings.ToLower(state)]
-		if !ok {
-			return errValidation("Fehler: ungültiger --state Wert %q\nGültige Bundesländer: wien, niederoesterreich, oberoesterreich, salzburg, tirol, vorarlberg, kaernten, steiermark, burgenland", state)
-		}
-		params.Set("Bundesland", value)
-	}
-	if number != "" {
-		params.Set("Kundmachungsnummer", number)
-	}
-	if from != "" {
-		params.Set("Kundmachungsdatum.Von", from)
-	}
-	if to != "" {
-		params.Set("Kundmachungsdatum.Bis", to)
-	}
-
-	return executeSearch(cmd, "Landesrecht", "Suche in Verordnungsblättern...", params)
+	var q query.VerordnungenQuery
+	q.Search, _ = cmd.Flags().GetString("search")
+	q.Title, _ = cmd.Flags().GetString("title")
+	q.State, _ = cmd.Flags().GetString("state")
+	q.Number, _ = cmd.Flags().GetString("number")
+	q.From, _ = cmd.Flags().GetString("from")
+	q.To, _ = cmd.Flags().GetString("to")
+
+	return executeQuery(cmd, q, "Suche in Verordnungsblättern...")
 }
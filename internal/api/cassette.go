@@ -0,0 +1,318 @@
+package api
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// ReplayMatch selects how replayed requests are matched against recordings.
+type ReplayMatch string
+
+const (
+	// MatchStrict requires method, URL and all query parameters to be equal.
+	MatchStrict ReplayMatch = "strict"
+	// MatchLenient requires method and URL without query to be equal and picks
+	// the recording sharing the most query parameters with the request.
+	MatchLenient ReplayMatch = "lenient"
+)
+
+// valid reports whether m is a known mode; empty means MatchStrict.
+func (m ReplayMatch) valid() bool {
+	return m == "" || m == MatchStrict || m == MatchLenient
+}
+
+// recordedHeaders are the response headers kept in cassettes. Everything else
+// (cookies, server timing, dates) is dropped to keep recordings stable.
+var recordedHeaders = []string{"Content-Type", "ETag", "Last-Modified", "Retry-After"}
+
+// Interaction is one recorded request/response pair, stored as one JSON file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request. The query is stored as a map so that
+// the JSON encoding has sorted keys and diffs stay readable.
+type RecordedRequest struct {
+	Method string              `json:"method"`
+	URL    string              `json:"url"`
+	Query  map[string][]string `json:"query,omitempty"`
+}
+
+// RecordedResponse holds the response. JSON bodies are stored indented in
+// BodyJSON, other text bodies verbatim in Body. Bodies that are not valid
+// UTF-8 (PDF, RTF, …) are stored base64-encoded in BodyBase64, since a JSON
+// string cannot hold them unchanged.
+type RecordedResponse struct {
+	Status     int               `json:"status"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	BodyJSON   json.RawMessage   `json:"body_json,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	BodyBase64 []byte            `json:"body_base64,omitempty"`
+}
+
+// ReplayMissError indicates that no recording matches a request in replay mode.
+type ReplayMissError struct {
+	URL string
+	Dir string
+}
+
+func (e *ReplayMissError) Error() string {
+	return fmt.Sprintf("Keine Aufzeichnung für %s in %s", e.URL, e.Dir)
+}
+
+// newRecordedRequest converts a live request into its recorded form.
+func newRecordedRequest(req *http.Request) RecordedRequest {
+	u := *req.URL
+	query := u.Query()
+	u.RawQuery = ""
+	u.Fragment = ""
+	rr := RecordedRequest{Method: req.Method, URL: u.String()}
+	if len(query) > 0 {
+		rr.Query = query
+	}
+	return rr
+}
+
+// key returns the content address of a request: a hash over method, URL and
+// the canonically ordered query string.
+func (r RecordedRequest) key() string {
+	sum := sha256.Sum256([]byte(r.Method + " " + r.URL + "?" + url.Values(r.Query).Encode()))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// fileName returns a readable, stable file name for the interaction,
+// e.g. "Bundesrecht-3fa2c81b9d0e.json".
+func (r RecordedRequest) fileName() string {
+	name := "request"
+	if u, err := url.Parse(r.URL); err == nil {
+		if base := path.Base(u.Path); base != "/" && base != "." {
+			name = base
+		}
+	}
+	return unsafeFileChars.ReplaceAllString(name, "_") + "-" + r.key() + ".json"
+}
+
+// unsafeFileChars matches characters not allowed in cassette file names.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// recordingTransport passes requests to the next transport and saves every
+// response to the cassette directory. Responses larger than limit (0 = no
+// limit) fail with *ResponseTooLargeError and are not recorded.
+type recordingTransport struct {
+	dir   string
+	next  http.RoundTripper
+	limit int64
+	mu    sync.Mutex
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.limit > 0 && resp.ContentLength > t.limit {
+		resp.Body.Close()
+		return nil, &ResponseTooLargeError{URL: req.URL.String(), Limit: t.limit}
+	}
+	r := io.Reader(resp.Body)
+	if t.limit > 0 {
+		r = io.LimitReader(resp.Body, t.limit+1)
+	}
+	body, err := io.ReadAll(r)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if t.limit > 0 && int64(len(body)) > t.limit {
+		return nil, &ResponseTooLargeError{URL: req.URL.String(), Limit: t.limit}
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := t.save(newRecordedRequest(req), resp, body); err != nil {
+		return nil, fmt.Errorf("Aufzeichnung fehlgeschlagen: %w", err)
+	}
+	return resp, nil
+}
+
+func (t *recordingTransport) save(rr RecordedRequest, resp *http.Response, body []byte) error {
+	rec := RecordedResponse{Status: resp.StatusCode}
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			if rec.Headers == nil {
+				rec.Headers = map[string]string{}
+			}
+			rec.Headers[h] = v
+		}
+	}
+	var indented bytes.Buffer
+	switch {
+	case json.Valid(body) && json.Indent(&indented, body, "", "  ") == nil:
+		rec.BodyJSON = indented.Bytes()
+	case utf8.Valid(body):
+		rec.Body = string(body)
+	default:
+		rec.BodyBase64 = body
+	}
+
+	data, err := json.MarshalIndent(Interaction{Request: rr, Response: rec}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(t.dir, rr.fileName()), append(data, '\n'), 0o644)
+}
+
+// replayTransport answers requests from a cassette directory and never
+// touches the network.
+type replayTransport struct {
+	dir   string
+	match ReplayMatch
+
+	once         sync.Once
+	loadErr      error
+	interactions []Interaction // sorted by file name for deterministic matching
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.once.Do(t.load)
+	if t.loadErr != nil {
+		return nil, t.loadErr
+	}
+
+	rr := newRecordedRequest(req)
+	in, ok := t.find(rr)
+	if !ok {
+		return nil, &ReplayMissError{URL: req.URL.String(), Dir: t.dir}
+	}
+
+	body := []byte(in.Response.Body)
+	if in.Response.BodyBase64 != nil {
+		body = in.Response.BodyBase64
+	}
+	if len(in.Response.BodyJSON) > 0 {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, in.Response.BodyJSON); err != nil {
+			return nil, err
+		}
+		body = compact.Bytes()
+	}
+
+	header := http.Header{}
+	for k, v := range in.Response.Headers {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+		StatusCode:    in.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// load reads all interactions from the cassette directory.
+func (t *replayTransport) load() {
+	files, err := filepath.Glob(filepath.Join(t.dir, "*.json"))
+	if err != nil {
+		t.loadErr = err
+		return
+	}
+	if len(files) == 0 {
+		if _, err := os.Stat(t.dir); err != nil {
+			t.loadErr = fmt.Errorf("Aufzeichnungsverzeichnis nicht lesbar: %w", err)
+			return
+		}
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.loadErr = err
+			return
+		}
+		var in Interaction
+		if err := json.Unmarshal(data, &in); err != nil {
+			t.loadErr = fmt.Errorf("Aufzeichnung %s ungültig: %w", filepath.Base(f), err)
+			return
+		}
+		t.interactions = append(t.interactions, in)
+	}
+}
+
+// find returns the recorded interaction matching the request.
+func (t *replayTransport) find(rr RecordedRequest) (Interaction, bool) {
+	key := rr.key()
+	for _, in := range t.interactions {
+		if in.Request.key() == key {
+			return in, true
+		}
+	}
+	if t.match != MatchLenient {
+		return Interaction{}, false
+	}
+
+	best, bestScore := -1, -1
+	for i, in := range t.interactions {
+		if in.Request.Method != rr.Method || in.Request.URL != rr.URL {
+			continue
+		}
+		if score := matchingParams(rr.Query, in.Request.Query); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return Interaction{}, false
+	}
+	return t.interactions[best], true
+}
+
+// matchingParams counts the query parameters with identical values in both requests.
+func matchingParams(a, b map[string][]string) int {
+	n := 0
+	for k, av := range a {
+		if bv, ok := b[k]; ok && slices.Equal(av, bv) {
+			n++
+		}
+	}
+	return n
+}
+
+// newCassetteTransport wraps next for recording or replaces it for replay.
+// Replay takes precedence when both directories are set. Recorded responses
+// are limited to limit bytes (0 = no limit).
+func newCassetteTransport(next http.RoundTripper, recordDir, replayDir string, match ReplayMatch, limit int64) http.RoundTripper {
+	switch {
+	case replayDir != "":
+		match = cmp.Or(match, MatchStrict)
+		return &replayTransport{dir: replayDir, match: match}
+	case recordDir != "":
+		return &recordingTransport{dir: recordDir, next: next, limit: limit}
+	}
+	return next
+}
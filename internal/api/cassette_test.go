@@ -0,0 +1,193 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordOne records a single search against a test server into dir and
+// returns the server URL used for the recording.
+func recordOne(t *testing.T, dir string, params *Params) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write([]byte(`{"q":"` + r.URL.Query().Get("Suchworte") + `"}`))
+	}))
+	defer srv.Close()
+
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL, RecordDir: dir})
+	if _, err := client.Search(context.Background(), EndpointBundesrecht, params); err != nil {
+		t.Fatalf("recording Search: %v", err)
+	}
+	return srv.URL
+}
+
+func TestCassette_RecordWritesStableFile(t *testing.T) {
+	dir := t.TempDir()
+	params := NewParams()
+	params.Set("Suchworte", "Mietrecht")
+	recordOne(t, dir, params)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 cassette file, got %d", len(files))
+	}
+	if !strings.HasPrefix(filepath.Base(files[0]), "Bundesrecht-") {
+		t.Errorf("cassette file name %q should start with endpoint", filepath.Base(files[0]))
+	}
+
+	data, _ := os.ReadFile(files[0])
+	content := string(data)
+	if !strings.Contains(content, `"body_json"`) {
+		t.Error("JSON body should be stored as body_json")
+	}
+	if strings.Contains(content, "secret") {
+		t.Error("cassette must not contain unlisted headers like Set-Cookie")
+	}
+}
+
+func TestCassette_ReplayStrict(t *testing.T) {
+	dir := t.TempDir()
+	params := NewParams()
+	params.Set("Suchworte", "Mietrecht")
+	baseURL := recordOne(t, dir, params)
+
+	client := mustNewClient(ClientOptions{BaseURL: baseURL, ReplayDir: dir})
+	body, err := client.Search(context.Background(), EndpointBundesrecht, params)
+	if err != nil {
+		t.Fatalf("replay Search: %v", err)
+	}
+	if string(body) != `{"q":"Mietrecht"}` {
+		t.Errorf("replayed body = %q", body)
+	}
+
+	other := NewParams()
+	other.Set("Suchworte", "Mietrecht")
+	other.Set("Seitennummer", "2")
+	_, err = client.Search(context.Background(), EndpointBundesrecht, other)
+	var miss *ReplayMissError
+	if !errors.As(err, &miss) {
+		t.Fatalf("strict replay with extra param: expected *ReplayMissError, got %T: %v", err, err)
+	}
+}
+
+func TestCassette_ReplayLenient(t *testing.T) {
+	dir := t.TempDir()
+	params := NewParams()
+	params.Set("Suchworte", "Mietrecht")
+	baseURL := recordOne(t, dir, params)
+
+	client := mustNewClient(ClientOptions{BaseURL: baseURL, ReplayDir: dir, ReplayMatch: MatchLenient})
+	other := NewParams()
+	other.Set("Suchworte", "Mietrecht")
+	other.Set("Seitennummer", "2")
+
+	body, err := client.Search(context.Background(), EndpointBundesrecht, other)
+	if err != nil {
+		t.Fatalf("lenient replay: %v", err)
+	}
+	if string(body) != `{"q":"Mietrecht"}` {
+		t.Errorf("replayed body = %q", body)
+	}
+
+	if _, err := client.Search(context.Background(), EndpointJudikatur, params); err == nil {
+		t.Error("lenient replay must still require the same URL path")
+	}
+}
+
+func TestCassette_RejectsUnknownMatch(t *testing.T) {
+	_, err := NewClient(ClientOptions{ReplayDir: t.TempDir(), ReplayMatch: "lenent"})
+	if err == nil || !strings.Contains(err.Error(), "lenent") {
+		t.Fatalf("expected an error naming the unknown mode, got %v", err)
+	}
+}
+
+func TestCassette_BinaryBodyRoundTrip(t *testing.T) {
+	pdf := []byte{0x25, 0x50, 0xff, 0xfe, 0x00, 0x80}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(pdf)
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+	docURL := srv.URL + "/Dokumente/Bundesnormen/NOR1/NOR1.pdf"
+
+	readDoc := func(client *Client) []byte {
+		t.Helper()
+		body, err := client.OpenDocument(context.Background(), docURL, "application/pdf")
+		if err != nil {
+			t.Fatalf("OpenDocument: %v", err)
+		}
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	readDoc(mustNewClient(ClientOptions{BaseURL: srv.URL + "/", RecordDir: dir}))
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 cassette file, got %d", len(files))
+	}
+	if data, _ := os.ReadFile(files[0]); !strings.Contains(string(data), `"body_base64"`) {
+		t.Errorf("binary body should be stored as body_base64:\n%s", data)
+	}
+
+	if got := readDoc(mustNewClient(ClientOptions{BaseURL: srv.URL + "/", ReplayDir: dir})); !bytes.Equal(got, pdf) {
+		t.Errorf("replayed body = % x, want % x", got, pdf)
+	}
+}
+
+func TestCassette_ReplayMissIsNotRetried(t *testing.T) {
+	client := mustNewClient(ClientOptions{BaseURL: "https://data.bka.gv.at/ris/api/v2.6/", ReplayDir: t.TempDir(), Retries: 3})
+	calls := 0
+	client.sleep = func(context.Context, time.Duration) error {
+		calls++
+		return nil
+	}
+
+	_, err := client.Search(context.Background(), EndpointBundesrecht, nil)
+	var miss *ReplayMissError
+	if !errors.As(err, &miss) {
+		t.Fatalf("expected *ReplayMissError, got %T: %v", err, err)
+	}
+	if calls != 0 {
+		t.Errorf("replay miss was retried %d times", calls)
+	}
+}
+
+func TestCassette_RecordRespectsMaxResponseSize(t *testing.T) {
+	for _, chunked := range []bool{false, true} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if chunked {
+				w.(http.Flusher).Flush()
+			}
+			w.Write(bytes.Repeat([]byte("x"), 2048))
+		}))
+
+		dir := t.TempDir()
+		client := mustNewClient(ClientOptions{BaseURL: srv.URL, RecordDir: dir, MaxResponseSize: 1024})
+		_, err := client.Search(context.Background(), EndpointBundesrecht, nil)
+		srv.Close()
+
+		var tooLarge *ResponseTooLargeError
+		if !errors.As(err, &tooLarge) || tooLarge.Limit != 1024 {
+			t.Fatalf("chunked=%v: expected *ResponseTooLargeError, got %T: %v", chunked, err, err)
+		}
+		if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) != 0 {
+			t.Errorf("chunked=%v: oversized response was recorded: %v", chunked, files)
+		}
+	}
+}
@@ -1,7 +1,15 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/philrox/risgo/internal/cache"
 )
 
 // TestAllowedHosts_ExactEntries verifies that AllowedHosts contains exactly
@@ -140,3 +148,281 @@ func TestValidateDocURL_CaseInsensitiveHost(t *testing.T) {
 		})
 	}
 }
+
+// newCachedTestClient returns a client for srv backed by a fresh on-disk cache.
+func newCachedTestClient(t *testing.T, srv *httptest.Server, mode CacheMode) (*Client, *cache.Cache) {
+	t.Helper()
+	c := cache.New(t.TempDir())
+	return mustNewClient(ClientOptions{BaseURL: srv.URL, Cache: c, CacheMode: mode}), c
+}
+
+// TestSearch_CacheHit verifies that a fresh cached response is served without a second request.
+func TestSearch_CacheHit(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"n":1}`))
+	}))
+	defer srv.Close()
+
+	client, _ := newCachedTestClient(t, srv, CacheDefault)
+	params := NewParams()
+	params.Set("Suchworte", "test")
+
+	for i := 0; i < 2; i++ {
+		body, err := client.Search(context.Background(), EndpointBundesrecht, params)
+		if err != nil {
+			t.Fatalf("Search #%d: %v", i+1, err)
+		}
+		if string(body) != `{"n":1}` {
+			t.Errorf("Search #%d body = %q", i+1, body)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("server called %d times, want 1", calls)
+	}
+}
+
+// TestSearch_CacheRevalidation verifies that stale entries are revalidated
+// with If-None-Match and that a 304 answer returns the cached body.
+func TestSearch_CacheRevalidation(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"n":1}`))
+	}))
+	defer srv.Close()
+
+	client, _ := newCachedTestClient(t, srv, CacheRefresh)
+
+	if _, err := client.Search(context.Background(), EndpointBundesrecht, nil); err != nil {
+		t.Fatal(err)
+	}
+	body, err := client.Search(context.Background(), EndpointBundesrecht, nil)
+	if err != nil {
+		t.Fatalf("revalidated Search: %v", err)
+	}
+	if string(body) != `{"n":1}` {
+		t.Errorf("body after 304 = %q, want cached body", body)
+	}
+	if calls != 2 {
+		t.Errorf("server called %d times, want 2 (initial + revalidation)", calls)
+	}
+}
+
+// TestSearch_OfflineHit verifies that offline mode serves cached entries regardless of age.
+func TestSearch_OfflineHit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"n":1}`))
+	}))
+	defer srv.Close()
+
+	online, c := newCachedTestClient(t, srv, CacheDefault)
+	if _, err := online.Search(context.Background(), EndpointHistory, nil); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	offline := mustNewClient(ClientOptions{BaseURL: srv.URL, Cache: c, CacheMode: CacheOffline})
+	body, err := offline.Search(context.Background(), EndpointHistory, nil)
+	if err != nil {
+		t.Fatalf("offline Search: %v", err)
+	}
+	if string(body) != `{"n":1}` {
+		t.Errorf("offline body = %q", body)
+	}
+}
+
+// TestSearch_OfflineMiss verifies that offline mode reports a typed error on a cache miss.
+func TestSearch_OfflineMiss(t *testing.T) {
+	client := mustNewClient(ClientOptions{
+		BaseURL:   "http://127.0.0.1:1",
+		Cache:     cache.New(t.TempDir()),
+		CacheMode: CacheOffline,
+	})
+
+	_, err := client.Search(context.Background(), EndpointBundesrecht, nil)
+	var oe *OfflineError
+	if !errors.As(err, &oe) {
+		t.Fatalf("expected *OfflineError, got %T: %v", err, err)
+	}
+}
+
+// TestFetchDocument_SameOriginAsBaseURL verifies that documents on the configured
+// base URL (e.g. a local mock server) are allowed while other hosts stay rejected.
+func TestFetchDocument_SameOriginAsBaseURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<p>ok</p>"))
+	}))
+	defer srv.Close()
+
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL + "/"})
+	html, err := client.FetchDocument(context.Background(), srv.URL+"/Dokumente/Bundesnormen/NOR1/NOR1.html")
+	if err != nil {
+		t.Fatalf("FetchDocument on base URL origin: %v", err)
+	}
+	if html != "<p>ok</p>" {
+		t.Errorf("html = %q", html)
+	}
+
+	if _, err := client.FetchDocument(context.Background(), "http://evil.com/doc.html"); err == nil {
+		t.Error("FetchDocument must still reject foreign hosts")
+	}
+}
+
+func TestPublicAPI(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    bool
+	}{
+		{"", true},
+		{"https://data.bka.gv.at/ris/api/v2.6", true},
+		{"https://DATA.bka.gv.at/ris/api/v2.5/", true},
+		{"http://data.bka.gv.at/ris/api/v2.6/", false},
+		{"http://127.0.0.1:8080/", false},
+		{"https://ris-mirror.intern.example/ris/api/v2.6/", false},
+	}
+	for _, tt := range tests {
+		if got := mustNewClient(ClientOptions{BaseURL: tt.baseURL}).PublicAPI(); got != tt.want {
+			t.Errorf("PublicAPI() with base URL %q = %v, want %v", tt.baseURL, got, tt.want)
+		}
+	}
+}
+
+// mustNewClient creates a client for tests, panicking on invalid options.
+func mustNewClient(opts ClientOptions) *Client {
+	client, err := NewClient(opts)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// TestSearch_ResponseTooLarge verifies that oversized bodies are rejected,
+// whether announced via Content-Length or only noticed while streaming,
+// and that they are neither retried nor cached.
+func TestSearch_ResponseTooLarge(t *testing.T) {
+	for _, chunked := range []bool{false, true} {
+		var calls int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			body := strings.Repeat("x", 2048)
+			if chunked {
+				w.Write([]byte(body[:1000]))
+				w.(http.Flusher).Flush()
+				w.Write([]byte(body[1000:]))
+				return
+			}
+			w.Header().Set("Content-Length", "2048")
+			w.Write([]byte(body))
+		}))
+
+		c := cache.New(t.TempDir())
+		client := mustNewClient(ClientOptions{BaseURL: srv.URL, Cache: c, Retries: 2, MaxResponseSize: 1024})
+		_, err := client.Search(context.Background(), EndpointBundesrecht, nil)
+		srv.Close()
+
+		var tooLarge *ResponseTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("chunked=%v: expected *ResponseTooLargeError, got %T: %v", chunked, err, err)
+		}
+		if tooLarge.Limit != 1024 || !strings.Contains(err.Error(), "1 KiB") {
+			t.Errorf("chunked=%v: unexpected error %v", chunked, err)
+		}
+		if calls != 1 {
+			t.Errorf("chunked=%v: expected 1 request, got %d", chunked, calls)
+		}
+		if _, body, err := c.Open(cache.Key(srv.URL + "/Bundesrecht")); err == nil {
+			body.Close()
+			t.Errorf("chunked=%v: oversized response must not be cached", chunked)
+		}
+	}
+}
+
+// TestOpenSearch_CachesWhenClosedEarly verifies that a streamed response is
+// cached even if the reader stops before EOF, as a JSON decoder does.
+func TestOpenSearch_CachesWhenClosedEarly(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"ok":true}` + "\n"))
+	}))
+	defer srv.Close()
+
+	client, _ := newCachedTestClient(t, srv, CacheDefault)
+	body, err := client.OpenSearch(context.Background(), EndpointBundesrecht, nil)
+	if err != nil {
+		t.Fatalf("OpenSearch: %v", err)
+	}
+	buf := make([]byte, 4)
+	io.ReadFull(body, buf)
+	body.Close()
+
+	got, err := client.Search(context.Background(), EndpointBundesrecht, nil)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected second request to be served from cache, got %d requests", calls)
+	}
+	if string(got) != `{"ok":true}`+"\n" {
+		t.Errorf("cached body = %q", got)
+	}
+}
+
+func TestSearch_DoesNotCacheAPIErrors(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"OgdSearchResult":{"Error":{"Applikation":"BrKons","Message":"Seitennummer ungültig"}}}`))
+	}))
+	defer srv.Close()
+
+	client, _ := newCachedTestClient(t, srv, CacheDefault)
+	for range 2 {
+		if _, err := client.Search(context.Background(), EndpointBundesrecht, nil); err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected the rejection to be requested again, got %d requests", calls)
+	}
+}
+
+// TestOpenDocument_ContentType verifies that a document response with an
+// unexpected Content-Type is rejected and not cached, while matching or
+// missing Content-Types are accepted.
+func TestOpenDocument_ContentType(t *testing.T) {
+	contentType := "text/html; charset=utf-8"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["Content-Type"] = []string{contentType}
+		w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL + "/", Cache: cache.New(t.TempDir())})
+	docURL := srv.URL + "/Dokumente/Bundesnormen/NOR1/NOR1.pdf"
+
+	_, err := client.OpenDocument(context.Background(), docURL, "application/pdf")
+	var ctErr *ContentTypeError
+	if !errors.As(err, &ctErr) {
+		t.Fatalf("expected *ContentTypeError, got %T: %v", err, err)
+	}
+	if ctErr.ContentType != contentType {
+		t.Errorf("ContentType = %q", ctErr.ContentType)
+	}
+
+	for _, ct := range []string{"application/PDF", ""} {
+		contentType = ct
+		body, err := client.OpenDocument(context.Background(), docURL, "application/pdf")
+		if err != nil {
+			t.Fatalf("Content-Type %q: %v", ct, err)
+		}
+		body.Close()
+	}
+}
@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 const (
 	// DefaultBaseURL is the base URL for the RIS OGD API v2.6.
 	DefaultBaseURL = "https://data.bka.gv.at/ris/api/v2.6/"
@@ -14,9 +16,46 @@ const (
 	EndpointHistory     = "History"
 )
 
-// AllowedHosts for SSRF protection when fetching document content.
+// AllowedHosts for SSRF protection when fetching document content and
+// following redirects. Clients can add hosts via ClientOptions.AllowedHosts.
 var AllowedHosts = map[string]bool{
 	"data.bka.gv.at":    true,
 	"www.ris.bka.gv.at": true,
 	"ris.bka.gv.at":     true,
 }
+
+// DefaultMaxResponseSize bounds a single response body. Consolidated versions
+// of the largest laws are a few MiB of HTML; 100-document result pages with
+// full metadata stay well below 10 MiB.
+const DefaultMaxResponseSize = 64 << 20
+
+// maxRedirects caps the redirect chain of a single request.
+const maxRedirects = 5
+
+const (
+	// defaultSearchCacheTTL applies to endpoints without an entry in searchCacheTTLs.
+	defaultSearchCacheTTL = time.Hour
+
+	// documentCacheTTL is how long fetched document content stays fresh.
+	documentCacheTTL = 7 * 24 * time.Hour
+)
+
+// searchCacheTTLs defines how long cached search responses stay fresh per endpoint.
+// History changes continuously and is kept short; the collections change at most daily.
+var searchCacheTTLs = map[string]time.Duration{
+	EndpointBundesrecht: 24 * time.Hour,
+	EndpointLandesrecht: 24 * time.Hour,
+	EndpointJudikatur:   24 * time.Hour,
+	EndpointBezirke:     24 * time.Hour,
+	EndpointGemeinden:   24 * time.Hour,
+	EndpointSonstige:    24 * time.Hour,
+	EndpointHistory:     15 * time.Minute,
+}
+
+// searchCacheTTL returns the cache freshness lifetime for an endpoint.
+func searchCacheTTL(endpoint string) time.Duration {
+	if ttl, ok := searchCacheTTLs[endpoint]; ok {
+		return ttl
+	}
+	return defaultSearchCacheTTL
+}
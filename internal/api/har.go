@@ -0,0 +1,272 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// redactedHeaders are replaced with "[entfernt]" in HAR archives, since HAR
+// files are meant to be attached to bug reports.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// harMaxText bounds the response body captured per entry, so that large
+// documents are not held in memory until the archive is written.
+const harMaxText = 4 << 20
+
+// harCaptures reports whether a response body with the given Content-Type
+// is recorded as text. Binary content such as PDFs is recorded by size only.
+func harCaptures(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "json") || strings.HasSuffix(mediaType, "xml")
+}
+
+// HARRecorder collects the HTTP requests of an invocation for export as an
+// HTTP Archive (HAR 1.2). It is safe for concurrent use.
+type HARRecorder struct {
+	creator string
+	version string
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// NewHARRecorder creates an empty recorder. creator and version identify the
+// tool in the archive's "creator" field.
+func NewHARRecorder(creator, version string) *HARRecorder {
+	return &HARRecorder{creator: creator, version: version}
+}
+
+// Len returns the number of recorded requests.
+func (r *HARRecorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+// WriteTo writes the archive as JSON, with entries ordered by start time.
+func (r *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	entries := slices.Clone(r.entries)
+	r.mu.Unlock()
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].StartedDateTime < entries[j].StartedDateTime })
+	if entries == nil {
+		entries = []harEntry{}
+	}
+
+	doc := harDocument{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: r.creator, Version: r.version},
+		Entries: entries,
+	}}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return 0, err
+	}
+	return buf.WriteTo(w)
+}
+
+// WriteFile writes the archive to path.
+func (r *HARRecorder) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := r.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// add records a completed round trip. The caller holds timing.mu.
+func (r *HARRecorder) add(req *http.Request, resp *http.Response, timing *requestTiming, body *tracedBody) {
+	ms := func(d time.Duration) float64 {
+		if d < 0 {
+			return -1
+		}
+		return float64(d.Microseconds()) / 1000
+	}
+
+	var query []harNameValue
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			query = append(query, harNameValue{Name: name, Value: v})
+		}
+	}
+	sort.Slice(query, func(i, j int) bool { return query[i].Name < query[j].Name })
+
+	content := harContent{Size: body.n, MimeType: resp.Header.Get("Content-Type")}
+	switch data := body.buf.Bytes(); {
+	case !body.capture:
+		content.Comment = "binärer Inhalt nicht aufgezeichnet"
+	case body.truncated:
+		content.Comment = fmt.Sprintf("Inhalt nicht aufgezeichnet (größer als %d MiB)", harMaxText>>20)
+	case utf8.Valid(data):
+		content.Text = string(data)
+	}
+
+	connect := phase(timing.connectStart, timing.connectDone)
+	ssl := phase(timing.tlsStart, timing.tlsDone)
+	if connect >= 0 && ssl >= 0 {
+		// HAR counts the TLS handshake as part of "connect".
+		connect += ssl
+	}
+	send := phase(timing.gotConn, timing.wroteRequest)
+	wait := phase(timing.wroteRequest, timing.firstByte)
+	if send < 0 {
+		send = 0
+	}
+	if wait < 0 {
+		wait = phase(timing.start, timing.firstByte)
+	}
+
+	entry := harEntry{
+		StartedDateTime: timing.start.Format(time.RFC3339Nano),
+		Time:            ms(phase(timing.start, timing.end)),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         req.URL.String(),
+			HTTPVersion: req.Proto,
+			Headers:     harHeaders(req.Header),
+			QueryString: query,
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    0,
+		},
+		Response: harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  strings.TrimSpace(strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode))),
+			HTTPVersion: resp.Proto,
+			Headers:     harHeaders(resp.Header),
+			Cookies:     []harNameValue{},
+			Content:     content,
+			RedirectURL: resp.Header.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    body.n,
+		},
+		Cache: struct{}{},
+		Timings: harTimings{
+			Blocked: -1,
+			DNS:     ms(phase(timing.dnsStart, timing.dnsDone)),
+			Connect: ms(connect),
+			SSL:     ms(ssl),
+			Send:    ms(send),
+			Wait:    ms(wait),
+			Receive: ms(max(phase(timing.firstByte, timing.end), 0)),
+		},
+		ServerIPAddress: timing.remoteAddr,
+	}
+	if entry.Request.HTTPVersion == "" {
+		entry.Request.HTTPVersion = "HTTP/1.1"
+	}
+
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	r.mu.Unlock()
+}
+
+// harHeaders converts headers to HAR name/value pairs in a stable order,
+// redacting credentials.
+func harHeaders(h http.Header) []harNameValue {
+	out := []harNameValue{}
+	for name, values := range h {
+		for _, v := range values {
+			if slices.Contains(redactedHeaders, http.CanonicalHeaderKey(name)) {
+				v = "[entfernt]"
+			}
+			out = append(out, harNameValue{Name: name, Value: v})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// HAR 1.2 structures (http://www.softwareishard.com/blog/har-12-spec/).
+
+type harDocument struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	SSL     float64 `json:"ssl"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+
+	"github.com/philrox/risgo/internal/model"
+	"github.com/philrox/risgo/internal/parser"
+)
+
+// PageError reports a result page that could not be fetched while walking
+// pages concurrently. The walk continues with the following pages.
+type PageError struct {
+	Page int
+	Err  error
+}
+
+func (e *PageError) Error() string {
+	return fmt.Sprintf("Seite %d: %v", e.Page, e.Err)
+}
+
+func (e *PageError) Unwrap() error { return e.Err }
+
+// pageType describes a kind of result page for the generic page walkers.
+type pageType[T any] struct {
+	decode func(io.Reader) (T, error)
+	info   func(T) pageInfo
+	failed func(page int) T // placeholder yielded along with a *PageError
+}
+
+// pageInfo is the paging state of a decoded result page.
+type pageInfo struct {
+	totalHits int
+	pageSize  int
+	items     int
+	hasMore   bool
+}
+
+var searchPages = pageType[model.SearchResult]{
+	decode: parser.DecodeSearchResponse,
+	info: func(r model.SearchResult) pageInfo {
+		return pageInfo{r.TotalHits, r.PageSize, len(r.Documents), r.HasMore}
+	},
+	failed: func(page int) model.SearchResult { return model.SearchResult{Page: page} },
+}
+
+var historyPages = pageType[model.HistoryResult]{
+	decode: parser.DecodeHistoryResponse,
+	info: func(r model.HistoryResult) pageInfo {
+		return pageInfo{r.TotalHits, r.PageSize, len(r.Events), r.HasMore}
+	},
+	failed: func(page int) model.HistoryResult { return model.HistoryResult{Page: page} },
+}
+
+// SearchPages returns an iterator over consecutive result pages, starting at
+// the page set in params (Seitennummer, default 1). Each page is requested
+// only when the previous one has been consumed. Iteration ends after the last
+// page, when the caller stops, or after yielding the first error.
+func (c *Client) SearchPages(ctx context.Context, endpoint string, params *Params) iter.Seq2[model.SearchResult, error] {
+	return walkPages(ctx, c, endpoint, params, searchPages)
+}
+
+// SearchPagesConcurrent is like SearchPages but, once the first page has
+// revealed the total hit count, fetches up to concurrency further pages in
+// parallel. Pages are still yielded in order. A page that fails is yielded as
+// a *PageError and the walk continues; failures of the first page and
+// cancellation of ctx end the iteration. Pages already in flight when the
+// caller stops are discarded.
+func (c *Client) SearchPagesConcurrent(ctx context.Context, endpoint string, params *Params, concurrency int) iter.Seq2[model.SearchResult, error] {
+	return walkPagesConcurrent(ctx, c, endpoint, params, concurrency, searchPages)
+}
+
+// SearchAll returns an iterator over the documents of all result pages.
+// Pages are fetched lazily as the caller consumes documents.
+func (c *Client) SearchAll(ctx context.Context, endpoint string, params *Params) iter.Seq2[model.Document, error] {
+	return func(yield func(model.Document, error) bool) {
+		for result, err := range c.SearchPages(ctx, endpoint, params) {
+			if err != nil {
+				yield(model.Document{}, err)
+				return
+			}
+			for _, doc := range result.Documents {
+				if !yield(doc, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// HistoryPages is like SearchPages for the History endpoint: it yields the
+// change events of consecutive result pages.
+func (c *Client) HistoryPages(ctx context.Context, params *Params) iter.Seq2[model.HistoryResult, error] {
+	return walkPages(ctx, c, EndpointHistory, params, historyPages)
+}
+
+// HistoryPagesConcurrent is like SearchPagesConcurrent for the History
+// endpoint.
+func (c *Client) HistoryPagesConcurrent(ctx context.Context, params *Params, concurrency int) iter.Seq2[model.HistoryResult, error] {
+	return walkPagesConcurrent(ctx, c, EndpointHistory, params, concurrency, historyPages)
+}
+
+// walkPages implements SearchPages for any page type.
+func walkPages[T any](ctx context.Context, c *Client, endpoint string, params *Params, pt pageType[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		p := params.Clone()
+		page := startPage(p)
+
+		for {
+			result, err := fetchPage(ctx, c, endpoint, p, page, pt.decode)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(result, nil) {
+				return
+			}
+			// An empty page ends the walk even if the server claims more hits.
+			if info := pt.info(result); !info.hasMore || info.items == 0 {
+				return
+			}
+			page++
+		}
+	}
+}
+
+// walkPagesConcurrent implements SearchPagesConcurrent for any page type.
+func walkPagesConcurrent[T any](ctx context.Context, c *Client, endpoint string, params *Params, concurrency int, pt pageType[T]) iter.Seq2[T, error] {
+	if concurrency <= 1 {
+		return walkPages(ctx, c, endpoint, params, pt)
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		p := params.Clone()
+		start := startPage(p)
+
+		first, err := fetchPage(ctx, c, endpoint, p, start, pt.decode)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		info := pt.info(first)
+		if !yield(first, nil) || !info.hasMore || info.items == 0 {
+			return
+		}
+		last := (info.totalHits + info.pageSize - 1) / info.pageSize
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type fetched struct {
+			result T
+			err    error
+		}
+		// pending holds one channel per launched page, in page order. At most
+		// concurrency requests are in flight at any time.
+		var pending []chan fetched
+		next := start + 1
+		launch := func() {
+			ch := make(chan fetched, 1)
+			pending = append(pending, ch)
+			go func(page int, p *Params) {
+				result, err := fetchPage(ctx, c, endpoint, p, page, pt.decode)
+				ch <- fetched{result, err}
+			}(next, p.Clone())
+			next++
+		}
+		for next <= last && len(pending) < concurrency {
+			launch()
+		}
+
+		for page := start + 1; len(pending) > 0; page++ {
+			f := <-pending[0]
+			pending = pending[1:]
+			if next <= last {
+				launch()
+			}
+
+			if f.err != nil {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(pt.failed(page), &PageError{Page: page, Err: f.err}) {
+					return
+				}
+				continue
+			}
+			if !yield(f.result, nil) {
+				return
+			}
+		}
+	}
+}
+
+// fetchPage fetches a single result page and decodes it with decode. p is
+// modified.
+func fetchPage[T any](ctx context.Context, c *Client, endpoint string, p *Params, page int, decode func(io.Reader) (T, error)) (T, error) {
+	var zero T
+	p.Set("Seitennummer", strconv.Itoa(page))
+	body, err := c.OpenSearch(ctx, endpoint, p)
+	if err != nil {
+		return zero, err
+	}
+	defer body.Close()
+	result, err := decode(body)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return zero, err
+	}
+	if err != nil {
+		return zero, fmt.Errorf("Antwort für Seite %d konnte nicht verarbeitet werden: %w", page, err)
+	}
+	return result, nil
+}
+
+// startPage returns the page number set in p, or 1.
+func startPage(p *Params) int {
+	if n, err := strconv.Atoi(p.Get("Seitennummer")); err == nil && n > 0 {
+		return n
+	}
+	return 1
+}
@@ -0,0 +1,259 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/pkg/ristest"
+)
+
+func startMock(t *testing.T) (*ristest.Server, *api.Client) {
+	t.Helper()
+	srv, err := ristest.NewServer(ristest.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Close)
+	return srv, newClient(t, srv.BaseURL())
+}
+
+func newClient(t *testing.T, baseURL string) *api.Client {
+	t.Helper()
+	client, err := api.NewClient(api.ClientOptions{BaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// TestSearchPages_WalksAllPages verifies that pages are requested until HasMore is false.
+func TestSearchPages_WalksAllPages(t *testing.T) {
+	srv, client := startMock(t)
+	params := api.NewParams()
+	params.Set("DokumenteProSeite", "Ten")
+
+	var pages []int
+	seen := map[string]bool{}
+	for result, err := range client.SearchPages(context.Background(), api.EndpointBundesrecht, params) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages = append(pages, result.Page)
+		for _, doc := range result.Documents {
+			if seen[doc.Dokumentnummer] {
+				t.Errorf("document %s returned twice", doc.Dokumentnummer)
+			}
+			seen[doc.Dokumentnummer] = true
+		}
+	}
+
+	if len(pages) != 3 || pages[0] != 1 || pages[2] != 3 {
+		t.Errorf("pages = %v, want [1 2 3]", pages)
+	}
+	if len(seen) != 24 {
+		t.Errorf("got %d documents, want 24", len(seen))
+	}
+	if got := srv.Handler.Requests(); got != 3 {
+		t.Errorf("server saw %d requests, want 3", got)
+	}
+	if params.Get("Seitennummer") != "" {
+		t.Error("SearchPages must not modify the caller's params")
+	}
+}
+
+// TestSearchPages_StartPage verifies that iteration starts at the given Seitennummer.
+func TestSearchPages_StartPage(t *testing.T) {
+	_, client := startMock(t)
+	params := api.NewParams()
+	params.Set("DokumenteProSeite", "Ten")
+	params.Set("Seitennummer", "2")
+
+	var pages []int
+	for result, err := range client.SearchPages(context.Background(), api.EndpointBundesrecht, params) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages = append(pages, result.Page)
+	}
+	if len(pages) != 2 || pages[0] != 2 {
+		t.Errorf("pages = %v, want [2 3]", pages)
+	}
+}
+
+// TestSearchAll_StopsLazily verifies that breaking out of SearchAll stops fetching further pages.
+func TestSearchAll_StopsLazily(t *testing.T) {
+	srv, client := startMock(t)
+	params := api.NewParams()
+	params.Set("DokumenteProSeite", "Ten")
+
+	n := 0
+	for _, err := range client.SearchAll(context.Background(), api.EndpointBundesrecht, params) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+		if n == 12 {
+			break
+		}
+	}
+	if got := srv.Handler.Requests(); got != 2 {
+		t.Errorf("server saw %d requests after 12 documents, want 2", got)
+	}
+}
+
+// TestSearchAll_Error verifies that a failing page ends the iteration with an error.
+func TestSearchAll_Error(t *testing.T) {
+	srv, err := ristest.NewServer(ristest.Options{FailFirst: 1, FailStatus: 500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	client := newClient(t, srv.BaseURL())
+
+	var errs int
+	for _, err := range client.SearchAll(context.Background(), api.EndpointBundesrecht, nil) {
+		if err != nil {
+			errs++
+		}
+	}
+	if errs != 1 {
+		t.Errorf("got %d errors, want 1", errs)
+	}
+}
+
+// pagedHandler serves totalHits results in pages of 10. Earlier pages respond
+// more slowly so that concurrent fetches complete out of order.
+func pagedHandler(totalHits int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("Seitennummer"))
+		time.Sleep(time.Duration(10-page) * 5 * time.Millisecond)
+
+		var refs []string
+		for i := (page - 1) * 10; i < min(page*10, totalHits); i++ {
+			refs = append(refs, fmt.Sprintf(`{"Data":{"Metadaten":{"Technisch":{"ID":"DOC%03d"}}}}`, i))
+		}
+		fmt.Fprintf(w, `{"OgdSearchResult":{"OgdDocumentResults":{"Hits":{"#text":"%d","@pageNumber":"%d","@pageSize":"10"},"OgdDocumentReference":[%s]}}}`,
+			totalHits, page, strings.Join(refs, ","))
+	}
+}
+
+// TestSearchPagesConcurrent_PreservesOrder verifies that pages fetched in parallel are yielded in page order.
+func TestSearchPagesConcurrent_PreservesOrder(t *testing.T) {
+	srv := httptest.NewServer(pagedHandler(45))
+	defer srv.Close()
+	client := newClient(t, srv.URL)
+
+	var ids []string
+	for result, err := range client.SearchPagesConcurrent(context.Background(), api.EndpointJudikatur, nil, 4) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, doc := range result.Documents {
+			ids = append(ids, doc.Dokumentnummer)
+		}
+	}
+
+	if len(ids) != 45 {
+		t.Fatalf("got %d documents, want 45", len(ids))
+	}
+	for i, id := range ids {
+		if want := fmt.Sprintf("DOC%03d", i); id != want {
+			t.Fatalf("document %d = %s, want %s (order not preserved)", i, id, want)
+		}
+	}
+}
+
+// TestHistoryPagesConcurrent_PreservesOrder verifies that the concurrent walk
+// also serves the History endpoint, in page order.
+func TestHistoryPagesConcurrent_PreservesOrder(t *testing.T) {
+	srv := httptest.NewServer(pagedHandler(25))
+	defer srv.Close()
+	client := newClient(t, srv.URL)
+
+	var ids []string
+	for result, err := range client.HistoryPagesConcurrent(context.Background(), nil, 3) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range result.Events {
+			ids = append(ids, e.Dokumentnummer)
+		}
+	}
+
+	if len(ids) != 25 {
+		t.Fatalf("got %d events, want 25", len(ids))
+	}
+	for i, id := range ids {
+		if want := fmt.Sprintf("DOC%03d", i); id != want {
+			t.Fatalf("event %d = %s, want %s (order not preserved)", i, id, want)
+		}
+	}
+}
+
+// TestSearchPagesConcurrent_PartialFailure verifies that a failing page is reported
+// as *PageError while the remaining pages are still delivered.
+func TestSearchPagesConcurrent_PartialFailure(t *testing.T) {
+	srv, err := ristest.NewServer(ristest.Options{RateLimitEvery: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	client := newClient(t, srv.BaseURL())
+
+	params := api.NewParams()
+	params.Set("DokumenteProSeite", "Ten")
+
+	var pages, failed, docs int
+	for result, err := range client.SearchPagesConcurrent(context.Background(), api.EndpointBundesrecht, params, 2) {
+		var pageErr *api.PageError
+		switch {
+		case errors.As(err, &pageErr):
+			failed++
+			if pageErr.Page < 2 || pageErr.Page > 3 {
+				t.Errorf("unexpected failed page %d", pageErr.Page)
+			}
+		case err != nil:
+			t.Fatal(err)
+		default:
+			pages++
+			docs += len(result.Documents)
+		}
+	}
+
+	if pages != 2 || failed != 1 {
+		t.Errorf("pages = %d, failed = %d; want 2 and 1", pages, failed)
+	}
+	if docs == 0 || docs == 24 {
+		t.Errorf("got %d documents, want a partial result", docs)
+	}
+}
+
+// TestSearchPagesConcurrent_FirstPageFails verifies that a failing first page ends the walk.
+func TestSearchPagesConcurrent_FirstPageFails(t *testing.T) {
+	srv, err := ristest.NewServer(ristest.Options{FailFirst: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	client := newClient(t, srv.BaseURL())
+
+	n := 0
+	for _, err := range client.SearchPagesConcurrent(context.Background(), api.EndpointBundesrecht, nil, 4) {
+		n++
+		var pageErr *api.PageError
+		if err == nil || errors.As(err, &pageErr) {
+			t.Errorf("expected a plain error, got %v", err)
+		}
+	}
+	if n != 1 || srv.Handler.Requests() != 1 {
+		t.Errorf("yielded %d times after %d requests, want 1 and 1", n, srv.Handler.Requests())
+	}
+}
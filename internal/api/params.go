@@ -1,6 +1,9 @@
 package api
 
-import "net/url"
+import (
+	"net/url"
+	"slices"
+)
 
 // Params wraps url.Values to provide a convenient builder for API query parameters.
 type Params struct {
@@ -31,3 +34,15 @@ func (p *Params) Encode() string {
 func (p *Params) Values() url.Values {
 	return p.values
 }
+
+// Clone returns an independent copy of p. Cloning a nil Params yields an empty one.
+func (p *Params) Clone() *Params {
+	if p == nil {
+		return NewParams()
+	}
+	values := make(url.Values, len(p.values))
+	for k, v := range p.values {
+		values[k] = slices.Clone(v)
+	}
+	return &Params{values: values}
+}
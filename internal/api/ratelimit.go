@@ -0,0 +1,150 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/philrox/risgo/internal/cache"
+)
+
+const (
+	// lockRetryInterval is the polling interval while waiting for the shared lock.
+	lockRetryInterval = 5 * time.Millisecond
+	// lockTimeout bounds how long a request waits for the shared lock.
+	lockTimeout = 2 * time.Second
+	// lockStaleAfter is the age after which a lock file is considered abandoned
+	// (e.g. left behind by a killed process) and removed.
+	lockStaleAfter = 10 * time.Second
+)
+
+// rateLimiter schedules outgoing requests. reserve claims the next request
+// slot and returns how long the caller must wait before sending.
+// Implementations must be safe for concurrent use.
+type rateLimiter interface {
+	reserve() (time.Duration, error)
+}
+
+// newRateLimiter returns the limiter for the given budget, or nil if rate is
+// not positive. A non-empty stateFile coordinates the budget across processes.
+func newRateLimiter(rate float64, burst int, stateFile string) rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	burst = max(burst, 1)
+	if stateFile != "" {
+		return &fileLimiter{path: stateFile, interval: rateInterval(rate), burst: burst, now: time.Now}
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), now: time.Now}
+}
+
+// rateInterval converts requests per second into the spacing between requests.
+func rateInterval(rate float64) time.Duration {
+	return time.Duration(float64(time.Second) / rate)
+}
+
+// tokenBucket is an in-process token bucket shared by all requests of a Client.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // bucket capacity
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func (b *tokenBucket) reserve() (time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+
+	// Tokens may go negative: each waiting caller queues behind the previous one.
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0, nil
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second)), nil
+}
+
+// fileLimiter coordinates a request budget across processes through a small
+// state file. It implements the generic cell rate algorithm (GCRA): the file
+// stores the theoretical arrival time (TAT) of the next request, and a request
+// may be sent once now >= TAT - (burst-1)*interval. Access is serialized with
+// an exclusive lock file next to the state file.
+type fileLimiter struct {
+	path     string
+	interval time.Duration
+	burst    int
+	now      func() time.Time
+}
+
+func (l *fileLimiter) reserve() (time.Duration, error) {
+	unlock, err := l.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	now := l.now()
+	tat := l.readTAT()
+	if tat.Before(now) {
+		tat = now
+	}
+	tolerance := time.Duration(l.burst-1) * l.interval
+	wait := max(tat.Add(-tolerance).Sub(now), 0)
+
+	state := strconv.FormatInt(tat.Add(l.interval).UnixNano(), 10)
+	return wait, cache.WriteFileAtomic(l.path, []byte(state))
+}
+
+// readTAT reads the stored theoretical arrival time. A missing or corrupt
+// state file yields the zero time, i.e. a full burst is available.
+func (l *fileLimiter) readTAT() time.Time {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return time.Time{}
+	}
+	nanos, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// lock acquires the exclusive lock file and returns its release function.
+func (l *fileLimiter) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return nil, err
+	}
+	lockPath := l.path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > lockStaleAfter {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("Sperre %s nicht erhalten", lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for limiter tests.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestNewRateLimiter_DisabledForZeroRate(t *testing.T) {
+	if l := newRateLimiter(0, 5, ""); l != nil {
+		t.Errorf("newRateLimiter(0, ...) = %T, want nil", l)
+	}
+}
+
+func TestTokenBucket_BurstThenSpacing(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := newRateLimiter(2, 3, "").(*tokenBucket)
+	b.now = clock.now
+
+	// Three requests fit into the burst.
+	for i := 0; i < 3; i++ {
+		if d, _ := b.reserve(); d != 0 {
+			t.Fatalf("reserve #%d = %v, want 0 within burst", i+1, d)
+		}
+	}
+	// The next two queue behind each other at 2 req/s.
+	if d, _ := b.reserve(); d != 500*time.Millisecond {
+		t.Errorf("4th reserve = %v, want 500ms", d)
+	}
+	if d, _ := b.reserve(); d != time.Second {
+		t.Errorf("5th reserve = %v, want 1s", d)
+	}
+
+	// After a long pause the bucket is full again, but never above burst.
+	clock.advance(time.Minute)
+	for i := 0; i < 3; i++ {
+		if d, _ := b.reserve(); d != 0 {
+			t.Fatalf("reserve after refill #%d = %v, want 0", i+1, d)
+		}
+	}
+	if d, _ := b.reserve(); d == 0 {
+		t.Error("bucket refilled beyond burst")
+	}
+}
+
+// TestFileLimiter_SharedAcrossInstances simulates two processes sharing one state file.
+func TestFileLimiter_SharedAcrossInstances(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	path := filepath.Join(t.TempDir(), "ratelimit.state")
+
+	a := newRateLimiter(1, 2, path).(*fileLimiter)
+	b := newRateLimiter(1, 2, path).(*fileLimiter)
+	a.now, b.now = clock.now, clock.now
+
+	waits := make([]time.Duration, 0, 4)
+	for _, l := range []*fileLimiter{a, b, a, b} {
+		d, err := l.reserve()
+		if err != nil {
+			t.Fatalf("reserve: %v", err)
+		}
+		waits = append(waits, d)
+	}
+
+	want := []time.Duration{0, 0, time.Second, 2 * time.Second}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Errorf("wait #%d = %v, want %v (all waits: %v)", i+1, waits[i], want[i], waits)
+		}
+	}
+}
+
+func TestFileLimiter_RemovesStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.state")
+	l := newRateLimiter(10, 1, path).(*fileLimiter)
+
+	// Simulate a crashed process that never released the lock.
+	if err := os.WriteFile(path+".lock", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * lockStaleAfter)
+	if err := os.Chtimes(path+".lock", old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := l.reserve(); err != nil {
+		t.Errorf("reserve with stale lock: %v", err)
+	}
+}
+
+func TestSearch_ThrottledByRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL, RateLimit: 1, RateBurst: 1})
+	var waits []time.Duration
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Search(context.Background(), EndpointBundesrecht, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(waits) != 1 || waits[0] <= 0 {
+		t.Errorf("waits = %v, want one positive wait for the second request", waits)
+	}
+}
+
+func TestReplay_NotThrottled(t *testing.T) {
+	client := mustNewClient(ClientOptions{ReplayDir: t.TempDir(), RateLimit: 1, RateBurst: 1})
+	if client.limiter != nil {
+		t.Error("replay client must not be rate limited")
+	}
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestValidateHostURL_ExtraHosts verifies that configured mirror hosts are
+// accepted in addition to the defaults, under the same HTTPS rule.
+func TestValidateHostURL_ExtraHosts(t *testing.T) {
+	extra, err := parseAllowedHosts([]string{"RIS-Mirror.intern.example", "ris.example:8443"})
+	if err != nil {
+		t.Fatalf("parseAllowedHosts: %v", err)
+	}
+
+	accepted := []string{
+		"https://ris-mirror.intern.example/Dokumente/Bundesnormen/NOR1/NOR1.html",
+		"https://ris.example:8443/Dokument.wxe",
+		"https://data.bka.gv.at/ris/api/v2.6/Bundesrecht",
+	}
+	for _, u := range accepted {
+		if err := validateHostURL(u, extra); err != nil {
+			t.Errorf("validateHostURL(%q) = %v, want nil", u, err)
+		}
+	}
+
+	rejected := []string{
+		"http://ris-mirror.intern.example/Dokument.wxe",
+		"https://evil.com/steal-data",
+		"https://sub.ris-mirror.intern.example/",
+	}
+	for _, u := range rejected {
+		if err := validateHostURL(u, extra); err == nil {
+			t.Errorf("validateHostURL(%q) = nil, want error", u)
+		}
+	}
+
+	if err := validateDocURL(accepted[0]); err == nil {
+		t.Error("extra hosts must not leak into the default allowlist")
+	}
+}
+
+// TestParseAllowedHosts_Rejected verifies that only exact host names are accepted.
+func TestParseAllowedHosts_Rejected(t *testing.T) {
+	tests := []struct {
+		name string
+		host string
+	}{
+		{"wildcard", "*.example.com"},
+		{"scheme", "https://mirror.example"},
+		{"path", "mirror.example/ris"},
+		{"userinfo", "user@mirror.example"},
+		{"port only", ":443"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseAllowedHosts([]string{tt.host}); err == nil {
+				t.Errorf("parseAllowedHosts(%q) = nil error, want error", tt.host)
+			}
+		})
+	}
+}
+
+// TestCheckRedirect verifies the rules applied to each redirect hop.
+func TestCheckRedirect(t *testing.T) {
+	client := mustNewClient(ClientOptions{BaseURL: "http://127.0.0.1:8080/", AllowedHosts: []string{"ris-mirror.intern.example"}})
+
+	hop := func(rawURL string, depth int) error {
+		u, _ := url.Parse(rawURL)
+		return client.checkRedirect(&http.Request{URL: u}, make([]*http.Request, depth))
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		depth   int
+		allowed bool
+	}{
+		{"allowed host", "https://www.ris.bka.gv.at/Dokument.wxe", 1, true},
+		{"mirror host", "https://ris-mirror.intern.example/Dokument.wxe", 1, true},
+		{"base URL origin", "http://127.0.0.1:8080/Bundesrecht", 1, true},
+		{"foreign host", "https://evil.com/", 1, false},
+		{"HTTPS downgrade", "http://www.ris.bka.gv.at/Dokument.wxe", 1, false},
+		{"cloud metadata", "http://169.254.169.254/latest/meta-data/", 1, false},
+		{"too many hops", "https://www.ris.bka.gv.at/Dokument.wxe", maxRedirects, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := hop(tt.url, tt.depth)
+			if tt.allowed && err != nil {
+				t.Errorf("redirect to %q rejected: %v", tt.url, err)
+			}
+			var re *RedirectError
+			if !tt.allowed && !errors.As(err, &re) {
+				t.Errorf("redirect to %q: got %v, want *RedirectError", tt.url, err)
+			}
+		})
+	}
+}
+
+// TestSearch_RejectedRedirectIsNotRetried verifies that a redirect to a
+// foreign host fails the request without following or retrying it.
+func TestSearch_RejectedRedirectIsNotRetried(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Redirect(w, r, "https://evil.com/steal", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL, Retries: 3})
+	_, err := client.Search(context.Background(), EndpointBundesrecht, nil)
+
+	var re *RedirectError
+	if !errors.As(err, &re) {
+		t.Fatalf("expected *RedirectError, got %T: %v", err, err)
+	}
+	if re.URL != "https://evil.com/steal" {
+		t.Errorf("RedirectError.URL = %q", re.URL)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+}
+
+// TestSearch_SameOriginRedirectIsFollowed verifies that redirects within the
+// base URL's origin still work.
+func TestSearch_SameOriginRedirectIsFollowed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/Bundesrecht" {
+			http.Redirect(w, r, "/v2/Bundesrecht", http.StatusMovedPermanently)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL})
+	if _, err := client.Search(context.Background(), EndpointBundesrecht, nil); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// retryBaseDelay is the backoff before the first retry; it doubles per attempt.
+	retryBaseDelay = 500 * time.Millisecond
+
+	// retryMaxDelay caps the computed backoff. Longer Retry-After values are
+	// only honored if they end before the context's deadline.
+	retryMaxDelay = 30 * time.Second
+)
+
+// isRetryableStatus reports whether a response status indicates a transient
+// server-side condition worth retrying.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before retry number attempt (1-based):
+// exponential growth from retryBaseDelay with "equal jitter", so the delay
+// lies between half and the full exponential value.
+func backoff(attempt int) time.Duration {
+	d := retryBaseDelay << (attempt - 1)
+	if d <= 0 || d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter interprets a Retry-After header given either as delay in
+// seconds or as HTTP date. Returns false if the header is absent or invalid.
+// The delay is not capped; see retryAfterFits.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	var d time.Duration
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		// Capped so that huge values cannot overflow into an immediate retry.
+		d = time.Duration(min(secs, math.MaxInt64/int64(time.Second))) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		d = t.Sub(now)
+	} else {
+		return 0, false
+	}
+	return max(d, 0), true
+}
+
+// retryAfterFits reports whether the client waits d, as requested by the
+// server, before retrying: up to retryMaxDelay always, longer only if the
+// wait ends before ctx's deadline.
+func retryAfterFits(ctx context.Context, d time.Duration) bool {
+	if d <= retryMaxDelay {
+		return true
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && d < time.Until(deadline)
+}
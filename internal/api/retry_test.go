@@ -0,0 +1,274 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient returns a client for srv whose waits are recorded instead of slept.
+func newRetryTestClient(srv *httptest.Server, retries int) (*Client, *[]time.Duration) {
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL, Retries: retries})
+	var waits []time.Duration
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return client, &waits
+}
+
+func TestSearch_RetriesTransientStatus(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client, waits := newRetryTestClient(srv, 3)
+	if _, err := client.Search(context.Background(), EndpointBundesrecht, nil); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("server called %d times, want 3", calls)
+	}
+	if len(*waits) != 2 {
+		t.Errorf("waited %d times, want 2", len(*waits))
+	}
+}
+
+func TestSearch_HonorsRetryAfter(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client, waits := newRetryTestClient(srv, 1)
+	if _, err := client.Search(context.Background(), EndpointBundesrecht, nil); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Errorf("waits = %v, want [7s]", *waits)
+	}
+}
+
+func TestSearch_LongRetryAfter(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// Without a deadline the client gives up instead of waiting two minutes,
+	// naming the requested delay.
+	client, waits := newRetryTestClient(srv, 1)
+	_, err := client.Search(context.Background(), EndpointBundesrecht, nil)
+	var he *HTTPError
+	if !errors.As(err, &he) || he.RetryAfter != 2*time.Minute {
+		t.Fatalf("expected *HTTPError with RetryAfter 2m, got %T: %v", err, err)
+	}
+	if !strings.Contains(err.Error(), "2m0s Wartezeit") || len(*waits) != 0 || calls != 1 {
+		t.Errorf("err = %v, waits = %v, calls = %d", err, *waits, calls)
+	}
+
+	// A deadline with room for the delay lets the client wait it out.
+	calls = 0
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	client, waits = newRetryTestClient(srv, 1)
+	if _, err := client.Search(ctx, EndpointBundesrecht, nil); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 2*time.Minute {
+		t.Errorf("waits = %v, want [2m0s]", *waits)
+	}
+}
+
+func TestSearch_ReportsAttemptsInHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	client, _ := newRetryTestClient(srv, 2)
+	_, err := client.Search(context.Background(), EndpointBundesrecht, nil)
+
+	var he *HTTPError
+	if !errors.As(err, &he) {
+		t.Fatalf("expected *HTTPError, got %T: %v", err, err)
+	}
+	if he.Attempts != 3 {
+		t.Errorf("Attempts = %d, want 3", he.Attempts)
+	}
+}
+
+func TestSearch_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client, waits := newRetryTestClient(srv, 3)
+	if _, err := client.Search(context.Background(), EndpointBundesrecht, nil); err == nil {
+		t.Fatal("expected error for HTTP 404")
+	}
+	if calls != 1 || len(*waits) != 0 {
+		t.Errorf("calls = %d, waits = %d; want 1 call and no retries", calls, len(*waits))
+	}
+}
+
+func TestSearch_RetriesConnectionErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close()
+
+	client, waits := newRetryTestClient(srv, 2)
+	_, err := client.Search(context.Background(), EndpointBundesrecht, nil)
+
+	var re *RequestError
+	if !errors.As(err, &re) {
+		t.Fatalf("expected *RequestError, got %T: %v", err, err)
+	}
+	if re.Attempts != 3 {
+		t.Errorf("Attempts = %d, want 3", re.Attempts)
+	}
+	if len(*waits) != 2 {
+		t.Errorf("waited %d times, want 2", len(*waits))
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	opErr := func(err error) error { return &net.OpError{Op: "dial", Net: "tcp", Err: err} }
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"timeout", &TimeoutError{URL: "u"}, true},
+		{"connection refused", opErr(syscall.ECONNREFUSED), true},
+		{"connection reset", &url.Error{Op: "Get", URL: "u", Err: opErr(syscall.ECONNRESET)}, true},
+		{"closed early", &url.Error{Op: "Get", URL: "u", Err: io.EOF}, true},
+		{"temporary DNS failure", &net.DNSError{Err: "server misbehaving", Name: "ris.bka.gv.at", IsTemporary: true}, true},
+		{"unknown host", &net.DNSError{Err: "no such host", Name: "ris.bka.gv.at", IsNotFound: true}, false},
+		{"certificate", &url.Error{Op: "Get", URL: "u", Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}}, false},
+		{"redirect", &url.Error{Op: "Get", URL: "u", Err: &RedirectError{URL: "https://evil.com/"}}, false},
+		{"replay miss", &ReplayMissError{URL: "u"}, false},
+		{"url", &URLError{URL: "u", Reason: "nur HTTPS"}, false},
+		{"content type", &ContentTypeError{URL: "u", ContentType: "text/html"}, false},
+	}
+	for _, tt := range tests {
+		if got := isRetryableError(tt.err); got != tt.want {
+			t.Errorf("%s: isRetryableError = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBackoff_GrowsAndIsCapped(t *testing.T) {
+	for attempt := 1; attempt <= 10; attempt++ {
+		full := retryBaseDelay << (attempt - 1)
+		if full > retryMaxDelay {
+			full = retryMaxDelay
+		}
+		d := backoff(attempt)
+		if d < full/2 || d > full {
+			t.Errorf("backoff(%d) = %v, want within [%v, %v]", attempt, d, full/2, full)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "3", 3 * time.Second, true},
+		{"http date", "Mon, 01 Jan 2024 12:00:10 GMT", 10 * time.Second, true},
+		{"past date", "Mon, 01 Jan 2024 11:00:00 GMT", 0, true},
+		{"long", "3600", time.Hour, true},
+		{"overflowing", "9223372036854775807", math.MaxInt64 / time.Second * time.Second, true},
+		{"garbage", "soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSearch_CancelledContextStopsRetries(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL, Retries: 5})
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+
+	_, err := client.Search(ctx, EndpointBundesrecht, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %T: %v", err, err)
+	}
+	if calls != 1 {
+		t.Errorf("server called %d times, want 1", calls)
+	}
+}
+
+func TestSearch_ContextDeadlineIsTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL})
+	_, err := client.Search(ctx, EndpointBundesrecht, nil)
+
+	var te *TimeoutError
+	if !errors.As(err, &te) {
+		t.Fatalf("expected *TimeoutError, got %T: %v", err, err)
+	}
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"time"
+)
+
+// requestTiming collects the httptrace events of one HTTP round trip.
+// Zero times mean the phase did not happen (e.g. no DNS lookup on a reused
+// connection).
+type requestTiming struct {
+	mu sync.Mutex
+
+	start, dnsStart, dnsDone  time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	gotConn, wroteRequest     time.Time
+	firstByte, end            time.Time
+	reused                    bool
+	remoteAddr                string
+}
+
+func (t *requestTiming) set(field *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if field.IsZero() {
+		*field = time.Now()
+	}
+}
+
+func (t *requestTiming) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { t.set(&t.dnsStart) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { t.set(&t.dnsDone) },
+		ConnectStart:      func(string, string) { t.set(&t.connectStart) },
+		ConnectDone:       func(string, string, error) { t.set(&t.connectDone) },
+		TLSHandshakeStart: func() { t.set(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { t.set(&t.tlsDone) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			t.reused = info.Reused
+			if info.Conn != nil {
+				t.remoteAddr = info.Conn.RemoteAddr().String()
+			}
+			t.mu.Unlock()
+			t.set(&t.gotConn)
+		},
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.set(&t.wroteRequest) },
+		GotFirstResponseByte: func() { t.set(&t.firstByte) },
+	}
+}
+
+// phase returns the duration between two events, or -1 if either is missing.
+func phase(from, to time.Time) time.Duration {
+	if from.IsZero() || to.IsZero() {
+		return -1
+	}
+	return to.Sub(from)
+}
+
+// tracingTransport records timings of every round trip. It prints a timing
+// breakdown to out (if non-nil) and adds an entry to har (if non-nil) once the
+// response body has been consumed.
+type tracingTransport struct {
+	next http.RoundTripper
+	out  io.Writer
+	har  *HARRecorder
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timing := &requestTiming{start: time.Now()}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), timing.clientTrace()))
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		timing.set(&timing.end)
+		t.finish(req, nil, timing, nil, err)
+		return nil, err
+	}
+
+	body := &tracedBody{ReadCloser: resp.Body, capture: t.har != nil && harCaptures(resp.Header.Get("Content-Type"))}
+	body.done = func() {
+		timing.set(&timing.end)
+		t.finish(req, resp, timing, body, nil)
+	}
+	resp.Body = body
+	return resp, nil
+}
+
+// finish reports a completed round trip.
+func (t *tracingTransport) finish(req *http.Request, resp *http.Response, timing *requestTiming, body *tracedBody, err error) {
+	timing.mu.Lock()
+	defer timing.mu.Unlock()
+
+	if t.out != nil {
+		var b strings.Builder
+		fmt.Fprintf(&b, "TRACE %s %s\n", req.Method, req.URL)
+		if err != nil {
+			fmt.Fprintf(&b, "      Fehler nach %s: %v\n", fmtPhase(phase(timing.start, timing.end)), err)
+		} else {
+			conn := "neu"
+			if timing.reused {
+				conn = "wiederverwendet"
+			}
+			fmt.Fprintf(&b, "      %d · DNS %s · TCP %s · TLS %s · TTFB %s · gesamt %s · %s · Verbindung %s\n",
+				resp.StatusCode,
+				fmtPhase(phase(timing.dnsStart, timing.dnsDone)),
+				fmtPhase(phase(timing.connectStart, timing.connectDone)),
+				fmtPhase(phase(timing.tlsStart, timing.tlsDone)),
+				fmtPhase(phase(timing.start, timing.firstByte)),
+				fmtPhase(phase(timing.start, timing.end)),
+				fmtSize(body.n),
+				conn)
+		}
+		io.WriteString(t.out, b.String())
+	}
+
+	if t.har != nil && resp != nil {
+		t.har.add(req, resp, timing, body)
+	}
+}
+
+// fmtPhase formats a phase duration; missing phases are shown as "–".
+func fmtPhase(d time.Duration) string {
+	if d < 0 {
+		return "–"
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// fmtSize formats a byte count for trace output.
+func fmtSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// tracedBody counts (and optionally captures, up to harMaxText) the bytes
+// of a response body and calls done once, at EOF or Close, whichever comes
+// first.
+type tracedBody struct {
+	io.ReadCloser
+	n         int64
+	capture   bool
+	truncated bool // the body exceeded harMaxText; buf has been dropped
+	buf       bytes.Buffer
+	done      func()
+	once      sync.Once
+}
+
+func (b *tracedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	if b.capture && !b.truncated {
+		if b.buf.Len()+n > harMaxText {
+			b.truncated = true
+			b.buf = bytes.Buffer{}
+		} else {
+			b.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF {
+		b.once.Do(b.done)
+	}
+	return n, err
+}
+
+func (b *tracedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
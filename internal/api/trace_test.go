@@ -0,0 +1,172 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/philrox/risgo/internal/cache"
+)
+
+func TestTrace_PrintsTimingBreakdown(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	var trace bytes.Buffer
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL, Trace: &trace})
+	if _, err := client.Search(context.Background(), EndpointBundesrecht, nil); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	out := trace.String()
+	for _, want := range []string{"TRACE GET " + srv.URL + "/Bundesrecht", "200 · DNS", "TTFB", "gesamt", "11 B", "Verbindung neu"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("trace output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTrace_ReportsCacheOutcome(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	var trace bytes.Buffer
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL, Trace: &trace, Cache: cache.New(t.TempDir())})
+	for range 2 {
+		if _, err := client.Search(context.Background(), EndpointBundesrecht, nil); err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+	}
+
+	out := trace.String()
+	if !strings.Contains(out, "CACHE MISS") || !strings.Contains(out, "CACHE HIT") {
+		t.Errorf("expected CACHE MISS then CACHE HIT, got:\n%s", out)
+	}
+	if n := strings.Count(out, "TRACE GET"); n != 1 {
+		t.Errorf("expected 1 traced request, got %d", n)
+	}
+}
+
+func TestHAR_RecordsRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		if r.URL.Query().Get("Suchworte") == "fehlt" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"q":"` + r.URL.Query().Get("Suchworte") + `"}`))
+	}))
+	defer srv.Close()
+
+	har := NewHARRecorder("risgo", "1.2.3")
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL, HAR: har})
+	for _, q := range []string{"Mietrecht", "fehlt"} {
+		params := NewParams()
+		params.Set("Suchworte", q)
+		client.Search(context.Background(), EndpointBundesrecht, params)
+	}
+
+	path := filepath.Join(t.TempDir(), "out.har")
+	if err := har.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+
+	var doc harDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("HAR is not valid JSON: %v", err)
+	}
+	if doc.Log.Version != "1.2" || doc.Log.Creator.Name != "risgo" || doc.Log.Creator.Version != "1.2.3" {
+		t.Errorf("unexpected log header: %+v", doc.Log)
+	}
+	if len(doc.Log.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(doc.Log.Entries))
+	}
+
+	first := doc.Log.Entries[0]
+	if first.Request.Method != "GET" || !strings.Contains(first.Request.URL, "Suchworte=Mietrecht") {
+		t.Errorf("unexpected request: %+v", first.Request)
+	}
+	if len(first.Request.QueryString) != 1 || first.Request.QueryString[0].Value != "Mietrecht" {
+		t.Errorf("unexpected queryString: %+v", first.Request.QueryString)
+	}
+	if first.Response.Status != 200 || first.Response.Content.Text != `{"q":"Mietrecht"}` {
+		t.Errorf("unexpected response: %+v", first.Response)
+	}
+	if first.Time < 0 || first.Timings.Wait < 0 {
+		t.Errorf("timings should be set: %+v", first.Timings)
+	}
+	for _, h := range first.Response.Headers {
+		if h.Name == "Set-Cookie" && h.Value != "[entfernt]" {
+			t.Errorf("Set-Cookie should be redacted, got %q", h.Value)
+		}
+	}
+
+	if status := doc.Log.Entries[1].Response.Status; status != 404 {
+		t.Errorf("failed request should be recorded with status 404, got %d", status)
+	}
+}
+
+func TestHAR_EmptyArchive(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := NewHARRecorder("risgo", "dev").WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"entries": []`) {
+		t.Errorf("empty archive should have an empty entries array:\n%s", buf.String())
+	}
+}
+
+func TestHAR_SkipsBinaryAndOversizedBodies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".pdf") {
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.7"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write(bytes.Repeat([]byte("x"), harMaxText+1))
+	}))
+	defer srv.Close()
+
+	har := NewHARRecorder("risgo", "dev")
+	client := mustNewClient(ClientOptions{BaseURL: srv.URL, HAR: har})
+	for _, path := range []string{"/doc.pdf", "/doc.html"} {
+		body, err := client.OpenDocument(context.Background(), srv.URL+path)
+		if err != nil {
+			t.Fatalf("OpenDocument(%s): %v", path, err)
+		}
+		io.Copy(io.Discard, body)
+		body.Close()
+	}
+
+	var buf bytes.Buffer
+	if _, err := har.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var doc harDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Log.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(doc.Log.Entries))
+	}
+	for i, want := range []int64{8, harMaxText + 1} {
+		c := doc.Log.Entries[i].Response.Content
+		if c.Text != "" || c.Comment == "" || c.Size != want {
+			t.Errorf("entry %d: size %d, comment %q, %d bytes of text; want size %d and a comment instead of text", i, c.Size, c.Comment, len(c.Text), want)
+		}
+	}
+}
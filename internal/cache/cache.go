@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Entry describes a cached HTTP response. The body is stored next to it.
+type Entry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ContentType  string    `json:"content_type,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+// Fresh reports whether the entry is younger than ttl at the given time.
+func (e *Entry) Fresh(ttl time.Duration, now time.Time) bool {
+	return now.Sub(e.StoredAt) < ttl
+}
+
+// Cache is a content-addressed on-disk store for HTTP responses.
+// Each entry consists of a metadata file (<key>.json) and a body file (<key>.body),
+// sharded into sub-directories by the first two characters of the key.
+// The cache has no size limit and never evicts entries: stale entries are
+// still served in offline mode. Deleting the directory is always safe.
+type Cache struct {
+	dir string
+}
+
+// New creates a cache rooted at dir. The directory is created on first write.
+func New(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// DefaultDir returns the cache directory: $RIS_CACHE_DIR if set,
+// otherwise "risgo" inside the user cache directory ($XDG_CACHE_HOME on Linux).
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("RIS_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("Cache-Verzeichnis nicht ermittelbar: %w", err)
+	}
+	return filepath.Join(base, "risgo"), nil
+}
+
+// Dir returns the root directory of the cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Key derives the content address for a request from its identifying parts
+// (typically the full request URL).
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Open returns the entry stored under key and a stream of its body, which
+// the caller must close. A missing entry is reported as an error satisfying
+// errors.Is(err, os.ErrNotExist).
+func (c *Cache) Open(key string) (*Entry, io.ReadCloser, error) {
+	meta, err := os.ReadFile(c.path(key, ".json"))
+	if err != nil {
+		return nil, nil, err
+	}
+	var e Entry
+	if err := json.Unmarshal(meta, &e); err != nil {
+		return nil, nil, fmt.Errorf("Cache-Eintrag beschädigt: %w", err)
+	}
+	body, err := os.Open(c.path(key, ".body"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &e, body, nil
+}
+
+// Create starts writing a new body for key. The entry becomes visible only
+// when Commit is called; Abort (or a failed write) discards it. Files are
+// moved into place atomically, so concurrent readers never observe partial
+// entries.
+func (c *Cache) Create(key string, e Entry) (*Writer, error) {
+	if err := os.MkdirAll(filepath.Dir(c.path(key, "")), 0o755); err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path(key, "")), ".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{cache: c, key: key, entry: e, tmp: tmp}, nil
+}
+
+// Writer streams a body into the cache. See Cache.Create.
+type Writer struct {
+	cache *Cache
+	key   string
+	entry Entry
+	tmp   *os.File
+	err   error
+}
+
+// Write appends to the body. After the first error all writes fail.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err := w.tmp.Write(p)
+	w.err = err
+	return n, err
+}
+
+// Commit moves the body into place and writes the metadata.
+func (w *Writer) Commit() error {
+	if err := errors.Join(w.err, w.tmp.Close()); err != nil {
+		os.Remove(w.tmp.Name())
+		return err
+	}
+	if err := os.Rename(w.tmp.Name(), w.cache.path(w.key, ".body")); err != nil {
+		os.Remove(w.tmp.Name())
+		return err
+	}
+	return w.cache.putMeta(w.key, w.entry)
+}
+
+// Abort discards the partially written body.
+func (w *Writer) Abort() {
+	w.tmp.Close()
+	os.Remove(w.tmp.Name())
+}
+
+// Touch replaces the metadata of an existing entry, e.g. after a successful
+// revalidation, without rewriting the body.
+func (c *Cache) Touch(key string, e Entry) error {
+	if _, err := os.Stat(c.path(key, ".body")); err != nil {
+		return err
+	}
+	return c.putMeta(key, e)
+}
+
+func (c *Cache) putMeta(key string, e Entry) error {
+	meta, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(c.path(key, ".json"), meta)
+}
+
+// path returns the file path for key with the given suffix.
+func (c *Cache) path(key, suffix string) string {
+	shard := key
+	if len(shard) > 2 {
+		shard = key[:2]
+	}
+	return filepath.Join(c.dir, shard, key+suffix)
+}
+
+// WriteFileAtomic writes data to a temporary file in the same directory and
+// renames it into place, so readers never observe a partially written file.
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, werr := tmp.Write(data)
+	cerr := tmp.Close()
+	if err := errors.Join(werr, cerr); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+// put stores an entry with the given body, failing the test on errors.
+func put(t *testing.T, c *Cache, key string, e Entry, body string) {
+	t.Helper()
+	w, err := c.Create(key, e)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	io.WriteString(w, body)
+	if err := w.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+}
+
+// get reads the entry and body stored under key.
+func get(c *Cache, key string) (*Entry, string, error) {
+	e, body, err := c.Open(key)
+	if err != nil {
+		return nil, "", err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	return e, string(data), err
+}
+
+func TestCreateOpen_RoundTrip(t *testing.T) {
+	c := New(t.TempDir())
+	key := Key("https://data.bka.gv.at/ris/api/v2.6/Bundesrecht?Suchworte=test")
+
+	entry := Entry{
+		URL:      "https://data.bka.gv.at/ris/api/v2.6/Bundesrecht?Suchworte=test",
+		ETag:     `"abc"`,
+		StoredAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	put(t, c, key, entry, `{"ok":true}`)
+
+	got, body, err := get(c, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if got.ETag != `"abc"` {
+		t.Errorf("ETag = %q, want %q", got.ETag, `"abc"`)
+	}
+	if !got.StoredAt.Equal(entry.StoredAt) {
+		t.Errorf("StoredAt = %v, want %v", got.StoredAt, entry.StoredAt)
+	}
+	if body != `{"ok":true}` {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestOpen_Miss(t *testing.T) {
+	c := New(t.TempDir())
+	_, _, err := c.Open(Key("missing"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Open on empty cache: err = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestTouch_UpdatesMetadataOnly(t *testing.T) {
+	c := New(t.TempDir())
+	key := Key("u")
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	put(t, c, key, Entry{URL: "u", StoredAt: old}, "body")
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := c.Touch(key, Entry{URL: "u", StoredAt: now}); err != nil {
+		t.Fatalf("Touch: %v", err)
+	}
+
+	got, body, err := get(c, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.StoredAt.Equal(now) {
+		t.Errorf("StoredAt = %v, want %v", got.StoredAt, now)
+	}
+	if body != "body" {
+		t.Errorf("body = %q, want %q", body, "body")
+	}
+}
+
+func TestTouch_MissingEntry(t *testing.T) {
+	c := New(t.TempDir())
+	if err := c.Touch(Key("missing"), Entry{}); err == nil {
+		t.Error("Touch on missing entry should fail")
+	}
+}
+
+func TestKey_StableAndDistinct(t *testing.T) {
+	if Key("a", "b") != Key("a", "b") {
+		t.Error("Key is not deterministic")
+	}
+	if Key("ab") == Key("a", "b") {
+		t.Error("Key must separate parts")
+	}
+}
+
+func TestEntry_Fresh(t *testing.T) {
+	stored := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	e := Entry{StoredAt: stored}
+
+	if !e.Fresh(time.Hour, stored.Add(30*time.Minute)) {
+		t.Error("entry should be fresh within TTL")
+	}
+	if e.Fresh(time.Hour, stored.Add(2*time.Hour)) {
+		t.Error("entry should be stale after TTL")
+	}
+}
+
+func TestDefaultDir_EnvOverride(t *testing.T) {
+	t.Setenv("RIS_CACHE_DIR", "/tmp/risgo-test-cache")
+	dir, err := DefaultDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != "/tmp/risgo-test-cache" {
+		t.Errorf("DefaultDir() = %q, want RIS_CACHE_DIR value", dir)
+	}
+}
+
+func TestCreate_CommitAndAbort(t *testing.T) {
+	c := New(t.TempDir())
+	key := Key("https://data.bka.gv.at/doc")
+
+	w, err := c.Create(key, Entry{URL: "https://data.bka.gv.at/doc"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	w.Write([]byte("<p>partial"))
+	w.Abort()
+	if _, _, err := c.Open(key); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("aborted entry should not exist, got %v", err)
+	}
+
+	w, _ = c.Create(key, Entry{URL: "https://data.bka.gv.at/doc"})
+	w.Write([]byte("<p>"))
+	w.Write([]byte("complete</p>"))
+	if err := w.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	e, body, err := get(c, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if body != "<p>complete</p>" || e.URL != "https://data.bka.gv.at/doc" {
+		t.Errorf("got entry %+v, body %q", e, body)
+	}
+}
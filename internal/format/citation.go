@@ -22,6 +22,11 @@ func FormatCitation(c *model.Citation) string {
 
 	var parts []string
 
+	// Court for decisions: "VfGH G123/2023 vom 2023-12-05"
+	if c.Gericht != "" && c.Gericht != c.Kurztitel {
+		parts = append(parts, c.Gericht)
+	}
+
 	// Paragraph + short title: "§ 1295 ABGB"
 	if c.Paragraph != "" && c.Kurztitel != "" {
 		parts = append(parts, citationParagraph(c.Paragraph+" "+c.Kurztitel))
@@ -36,6 +41,8 @@ func FormatCitation(c *model.Citation) string {
 	// Kundmachungsorgan in parentheses: "(JGS Nr. 946/1811)"
 	if c.Kundmachungsorgan != "" {
 		parts = append(parts, citationOrgan("("+c.Kundmachungsorgan+")"))
+	} else if c.Kundmachungsnummer != "" {
+		parts = append(parts, citationOrgan("(Nr. "+c.Kundmachungsnummer+")"))
 	}
 
 	// Geschaeftszahl for court decisions when no paragraph present.
@@ -46,6 +53,8 @@ func FormatCitation(c *model.Citation) string {
 	// Entscheidungsdatum for court decisions.
 	if c.Entscheidungsdatum != "" {
 		parts = append(parts, citationOrgan("vom "+c.Entscheidungsdatum))
+	} else if c.Kundmachungsdatum != "" {
+		parts = append(parts, citationOrgan("vom "+c.Kundmachungsdatum))
 	}
 
 	return strings.Join(parts, " ")
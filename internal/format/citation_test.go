@@ -77,6 +77,24 @@ func TestFormatCitation_GeschaeftszahlWithKurztitel(t *testing.T) {
 	}
 }
 
+func TestFormatCitation_Gericht(t *testing.T) {
+	c := &model.Citation{Gericht: "OGH", Geschaeftszahl: "1Ob1/24a", Entscheidungsdatum: "2024-01-15"}
+	got := FormatCitation(c)
+	want := "OGH 1Ob1/24a vom 2024-01-15"
+	if got != want {
+		t.Errorf("FormatCitation() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatCitation_Kundmachung(t *testing.T) {
+	c := &model.Citation{Kurztitel: "Halteverbot", Kundmachungsnummer: "12/2024", Kundmachungsdatum: "2024-01-01"}
+	got := FormatCitation(c)
+	want := "Halteverbot (Nr. 12/2024) vom 2024-01-01"
+	if got != want {
+		t.Errorf("FormatCitation() = %q, want %q", got, want)
+	}
+}
+
 func TestFormatCitation_Empty(t *testing.T) {
 	c := &model.Citation{}
 	got := FormatCitation(c)
@@ -0,0 +1,146 @@
+package format
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/philrox/risgo/internal/model"
+)
+
+// maxFileNameBytes keeps generated file names below the 255-byte limit of
+// common file systems, with room for a disambiguating suffix.
+const maxFileNameBytes = 200
+
+// FileNameTemplate renders file names for documents from a text/template
+// over model.Document, e.g. "{{.Kurztitel}}_{{.Citation.Paragraph}}.txt".
+type FileNameTemplate struct {
+	tmpl *template.Template
+}
+
+// ParseFileNameTemplate parses text and checks it against an empty document,
+// so unknown fields are reported before any document is fetched.
+func ParseFileNameTemplate(text string) (*FileNameTemplate, error) {
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	t := &FileNameTemplate{tmpl: tmpl}
+	if _, err := t.render(model.Document{}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Name renders the file name for doc. The result is a single, sanitized
+// path element; if the template renders no usable name before the
+// extension (e.g. "{{.Kurztitel}}.txt" for a document without Kurztitel),
+// the document number is used with the rendered extension.
+func (t *FileNameTemplate) Name(doc model.Document) (string, error) {
+	raw, err := t.render(doc)
+	if err != nil {
+		return "", err
+	}
+	if name := SanitizeFileName(raw); name != "" {
+		return name, nil
+	}
+	_, ext := splitFileName(raw)
+	if name := SanitizeFileName(doc.Dokumentnummer + ext); name != "" {
+		return name, nil
+	}
+	return "", fmt.Errorf("leerer Dateiname für Dokument %q", doc.Dokumentnummer)
+}
+
+func (t *FileNameTemplate) render(doc model.Document) (string, error) {
+	// Templates may refer to citation fields of documents without one.
+	if doc.Citation == nil {
+		doc.Citation = &model.Citation{}
+	}
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, doc); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// SanitizeFileName turns s into a file name that is safe on Linux, macOS
+// and Windows: path separators, reserved and control characters and
+// whitespace become "_", leading and trailing dots and underscores are
+// removed (the leading ones after splitting off the extension), reserved
+// Windows device names are prefixed and the name is shortened to
+// maxFileNameBytes, keeping the extension. It returns "" if nothing is left
+// before the extension, e.g. for ".txt".
+func SanitizeFileName(s string) string {
+	base, ext := splitFileName(s)
+	if base == "" {
+		return ""
+	}
+	if len(base)+len(ext) > maxFileNameBytes {
+		base = strings.TrimRight(truncateUTF8(base, maxFileNameBytes-len(ext)), "._")
+	}
+	if isReservedWindowsName(base) {
+		base = "_" + base
+	}
+	return base + ext
+}
+
+// splitFileName replaces the unsafe characters of s (see SanitizeFileName)
+// and splits the result into the name without leading dots and underscores
+// and the extension, which must be alphanumeric. Trailing dots, which
+// Windows drops, are removed before the extension is split off.
+func splitFileName(s string) (base, ext string) {
+	var b strings.Builder
+	underscore := false
+	for _, r := range s {
+		if r == utf8.RuneError || unicode.IsControl(r) || unicode.IsSpace(r) || strings.ContainsRune(`/\<>:"|?*`, r) {
+			r = '_'
+		}
+		if r == '_' {
+			if underscore {
+				continue
+			}
+			underscore = true
+		} else {
+			underscore = false
+		}
+		b.WriteRune(r)
+	}
+	name := strings.TrimRight(b.String(), "._")
+
+	ext = filepath.Ext(name)
+	if ext != "" && (len(ext) > 16 || strings.ContainsFunc(ext[1:], notAlphanumeric)) {
+		ext = ""
+	}
+	return strings.TrimLeft(strings.TrimSuffix(name, ext), "._"), ext
+}
+
+// notAlphanumeric reports whether r may not appear in a file extension.
+func notAlphanumeric(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// truncateUTF8 shortens s to at most n bytes without splitting a character.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// isReservedWindowsName reports whether base is a device name such as CON
+// or LPT1, which Windows does not allow as a file name with any extension.
+func isReservedWindowsName(base string) bool {
+	switch strings.ToUpper(base) {
+	case "CON", "PRN", "AUX", "NUL",
+		"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+		"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/philrox/risgo/internal/model"
+)
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"NOR40052761.txt", "NOR40052761.txt"},
+		{"ABGB_§ 1295_NOR40052761.txt", "ABGB_§_1295_NOR40052761.txt"},
+		{"../../etc/passwd", "etc_passwd"},
+		{`1 Ob 1/20a: "Test"?.pdf`, "1_Ob_1_20a_Test_.pdf"},
+		{"a\x00b\nc", "a_b_c"},
+		{"  ..hidden.txt.. ", "hidden.txt"},
+		{".txt", ""},
+		{"_ .pdf", ""},
+		{"CON.txt", "_CON.txt"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := SanitizeFileName(tt.in); got != tt.want {
+			t.Errorf("SanitizeFileName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizeFileName_TruncatesKeepingExtension(t *testing.T) {
+	got := SanitizeFileName(strings.Repeat("ä", 300) + ".txt")
+	if len(got) > maxFileNameBytes || !strings.HasSuffix(got, "ä.txt") {
+		t.Errorf("SanitizeFileName(long) = %q (%d bytes)", got, len(got))
+	}
+}
+
+func TestFileNameTemplate(t *testing.T) {
+	tmpl, err := ParseFileNameTemplate("{{.Kurztitel}}_{{.Citation.Paragraph}}_{{.Dokumentnummer}}.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := model.Document{
+		Dokumentnummer: "NOR40052761",
+		Kurztitel:      "ABGB",
+		Citation:       &model.Citation{Paragraph: "§ 1295"},
+	}
+	if got, _ := tmpl.Name(doc); got != "ABGB_§_1295_NOR40052761.txt" {
+		t.Errorf("Name = %q", got)
+	}
+
+	// Documents without citation must not fail.
+	if got, err := tmpl.Name(model.Document{Dokumentnummer: "JWT_1"}); err != nil || got != "JWT_1.txt" {
+		t.Errorf("Name without citation = %q, %v", got, err)
+	}
+}
+
+func TestFileNameTemplate_FallsBackToDokumentnummer(t *testing.T) {
+	tmpl, err := ParseFileNameTemplate("{{.Kurztitel}}.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := tmpl.Name(model.Document{Dokumentnummer: "NOR40052761"}); err != nil || got != "NOR40052761.txt" {
+		t.Errorf("Name without Kurztitel = %q, %v", got, err)
+	}
+
+	_, err = tmpl.Name(model.Document{})
+	if err == nil || !strings.Contains(err.Error(), "Dateiname") {
+		t.Errorf("expected German error for a document without name, got %v", err)
+	}
+}
+
+func TestParseFileNameTemplate_UnknownField(t *testing.T) {
+	if _, err := ParseFileNameTemplate("{{.Aktenzeichen}}.txt"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
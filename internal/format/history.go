@@ -0,0 +1,122 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/philrox/risgo/internal/model"
+)
+
+var boldRed = color.New(color.Bold, color.FgRed).SprintFunc()
+
+// changeLabels are the German display names of the change types.
+var changeLabels = map[model.ChangeType]string{
+	model.ChangeNew:     "neu",
+	model.ChangeUpdated: "geändert",
+	model.ChangeDeleted: "GELÖSCHT",
+}
+
+// TextHistory writes change events as human-readable text, grouped by day
+// with the newest day first. A result with Page 0 holds the events of all
+// pages (--all); HasMore then means the output was capped.
+func TextHistory(w io.Writer, result model.HistoryResult) error {
+	if len(result.Events) == 0 {
+		fmt.Fprintln(w, "Keine Änderungen gefunden.")
+		return nil
+	}
+
+	if result.Page == 0 {
+		fmt.Fprintln(w, bold(fmt.Sprintf("Änderungen: %d gesamt (alle Seiten, zeige %d)",
+			result.TotalHits, len(result.Events))))
+	} else {
+		fmt.Fprintln(w, bold(fmt.Sprintf("Änderungen: %d gesamt (Seite %d, zeige %d)",
+			result.TotalHits, result.Page, len(result.Events))))
+	}
+	fmt.Fprintln(w, dim(strings.Repeat("─", separatorWidth)))
+
+	deleted := 0
+	for _, day := range model.GroupByDay(result.Events) {
+		fmt.Fprintf(w, "\n%s\n", boldWhite(day.Datum))
+		for _, e := range day.Events {
+			if e.Aenderung == model.ChangeDeleted {
+				deleted++
+			}
+			writeHistoryEvent(w, e)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if deleted > 0 {
+		fmt.Fprintln(w, boldRed(fmt.Sprintf("Davon gelöscht: %d", deleted)))
+	}
+	if result.HasMore {
+		if result.Page == 0 {
+			fmt.Fprintln(w, boldYellow(fmt.Sprintf("Ausgabe nach %d von %d Änderungen begrenzt (--max-results).", len(result.Events), result.TotalHits)))
+		} else {
+			fmt.Fprintln(w, boldYellow(fmt.Sprintf("Weitere Änderungen verfügbar. Nächste Seite: --page %d", result.Page+1)))
+		}
+	}
+	return nil
+}
+
+// writeHistoryEvent writes one change event as a line below its day.
+func writeHistoryEvent(w io.Writer, e model.HistoryEvent) {
+	label := fmt.Sprintf("%-8s", changeLabels[e.Aenderung])
+	if e.Aenderung == model.ChangeDeleted {
+		label = boldRed(label)
+	} else if e.Aenderung == model.ChangeNew {
+		label = green(label)
+	}
+	fmt.Fprintf(w, "  %-5s  %s  %s  %s\n", e.Time(), label, cyan(e.Dokumentnummer), dim(e.Applikation))
+	if e.Titel != "" {
+		fmt.Fprintf(w, "%19s%s\n", "", e.Titel)
+	}
+}
+
+// historyJSON is the JSON form of a HistoryResult with events grouped by day.
+type historyJSON struct {
+	TotalHits int                `json:"total_hits"`
+	Page      int                `json:"page"`
+	PageSize  int                `json:"page_size"`
+	HasMore   bool               `json:"has_more"`
+	Days      []model.HistoryDay `json:"days"`
+}
+
+// JSONHistory writes change events as pretty-printed JSON, grouped by day
+// like TextHistory.
+func JSONHistory(w io.Writer, result model.HistoryResult) error {
+	days := model.GroupByDay(result.Events)
+	if days == nil {
+		days = []model.HistoryDay{}
+	}
+	data, err := json.MarshalIndent(historyJSON{
+		TotalHits: result.TotalHits,
+		Page:      result.Page,
+		PageSize:  result.PageSize,
+		HasMore:   result.HasMore,
+		Days:      days,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// NDJSONHistory writes change events as one compact JSON object per line, in
+// the order given, so that pages can be written as they arrive.
+func NDJSONHistory(w io.Writer, events []model.HistoryEvent) error {
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/philrox/risgo/internal/model"
+)
+
+var historyResult = model.HistoryResult{
+	TotalHits: 3,
+	Page:      1,
+	PageSize:  20,
+	Events: []model.HistoryEvent{
+		{Dokumentnummer: "NOR1", Applikation: "Bundesnormen", Titel: "§ 1 ABGB", Geaendert: "2024-06-30T10:15:00", Aenderung: model.ChangeUpdated},
+		{Dokumentnummer: "NOR2", Applikation: "Bundesnormen", Geaendert: "2024-07-01T08:02:11", Aenderung: model.ChangeNew},
+		{Dokumentnummer: "NOR3", Applikation: "Bundesnormen", Geaendert: "2024-07-01T09:00:00", Aenderung: model.ChangeDeleted},
+	},
+}
+
+func TestTextHistory_GroupsByDayNewestFirst(t *testing.T) {
+	var buf bytes.Buffer
+	if err := TextHistory(&buf, historyResult); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	order := []string{"2024-07-01", "09:00  GELÖSCHT  NOR3", "08:02  neu       NOR2", "2024-06-30", "10:15  geändert  NOR1", "§ 1 ABGB", "Davon gelöscht: 1"}
+	pos := 0
+	for _, s := range order {
+		i := strings.Index(out[pos:], s)
+		if i < 0 {
+			t.Fatalf("output missing %q after position %d:\n%s", s, pos, out)
+		}
+		pos += i + len(s)
+	}
+}
+
+func TestTextHistory_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := TextHistory(&buf, model.HistoryResult{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "Keine Änderungen gefunden.\n" {
+		t.Errorf("output = %q", got)
+	}
+}
+
+func TestJSONHistory_GroupsByDay(t *testing.T) {
+	var buf bytes.Buffer
+	if err := JSONHistory(&buf, historyResult); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		TotalHits int `json:"total_hits"`
+		Days      []struct {
+			Datum  string `json:"datum"`
+			Events []struct {
+				Dokumentnummer string `json:"dokumentnummer"`
+				Aenderung      string `json:"aenderung"`
+			} `json:"events"`
+		} `json:"days"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.TotalHits != 3 || len(got.Days) != 2 {
+		t.Fatalf("got %+v", got)
+	}
+	if got.Days[0].Datum != "2024-07-01" || len(got.Days[0].Events) != 2 || got.Days[0].Events[0].Aenderung != "geloescht" {
+		t.Errorf("first day = %+v", got.Days[0])
+	}
+}
+
+func TestNDJSONHistory_OneEventPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NDJSONHistory(&buf, historyResult.Events); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var e model.HistoryEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("line %d: %v", i+1, err)
+		}
+		if e.Dokumentnummer != historyResult.Events[i].Dokumentnummer {
+			t.Errorf("line %d: dokumentnummer = %q, want %q", i+1, e.Dokumentnummer, historyResult.Events[i].Dokumentnummer)
+		}
+	}
+}
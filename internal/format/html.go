@@ -1,84 +1,154 @@
 package format
 
 import (
+	"bufio"
 	"io"
 	"strings"
 
 	"golang.org/x/net/html"
 )
 
+// skippedElements are dropped together with their content.
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "head": true, "noscript": true, "title": true,
+}
+
+// blockElements start and end on their own line.
+var blockElements = map[string]bool{
+	"p": true, "div": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"li": true, "tr": true, "blockquote": true, "pre": true, "table": true,
+}
+
 // HTMLToText converts HTML content to plain text.
 // Strips script, style, and head elements. Normalizes whitespace.
 func HTMLToText(htmlContent string) string {
-	doc, err := html.Parse(strings.NewReader(htmlContent))
-	if err != nil {
+	var sb strings.Builder
+	if err := WriteHTMLText(&sb, strings.NewReader(htmlContent)); err != nil {
 		// Fallback: strip tags with simple approach.
 		return stripTagsSimple(htmlContent)
 	}
+	return sb.String()
+}
 
-	var sb strings.Builder
-	extractText(doc, &sb)
+// WriteHTMLText converts HTML read from r to plain text and writes it to w
+// as it goes, so memory use does not grow with the document size. The output
+// is the same as HTMLToText.
+func WriteHTMLText(w io.Writer, r io.Reader) error {
+	out := newTextWriter(w)
+	z := html.NewTokenizer(r)
+	skip := 0 // nesting depth inside skipped elements
+
+	for out.err == nil {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if err := z.Err(); err != io.EOF {
+				return err
+			}
+			return out.Flush()
+
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			if text := strings.TrimSpace(string(z.Text())); text != "" {
+				out.WriteString(text)
+				out.WriteString(" ")
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			switch {
+			case tag == "body":
+				skip = 0 // an unclosed <head> ends here
+			case skippedElements[tag]:
+				if tt == html.StartTagToken {
+					skip++
+				}
+			case skip > 0:
+			case tag == "br" || blockElements[tag]:
+				out.WriteString("\n")
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			switch {
+			case skippedElements[tag]:
+				skip = max(skip-1, 0)
+			case skip > 0:
+			case blockElements[tag]:
+				out.WriteString("\n")
+			}
+		}
+	}
+	return out.err
+}
 
-	// Normalize whitespace: collapse multiple blank lines.
-	text := sb.String()
-	text = normalizeWhitespace(text)
-	return strings.TrimSpace(text)
+// textWriter normalizes text line by line while writing it: lines are
+// trimmed, runs of blank lines collapse into one, and leading and trailing
+// blank lines are dropped.
+type textWriter struct {
+	w       *bufio.Writer
+	line    strings.Builder
+	started bool  // a non-blank line has been written
+	blank   bool  // blank lines are pending since the last written line
+	err     error // first write error
 }
 
-// extractText walks the HTML tree and extracts text content,
-// skipping script, style, and head elements.
-func extractText(n *html.Node, w io.StringWriter) {
-	if n.Type == html.ElementNode {
-		switch strings.ToLower(n.Data) {
-		case "script", "style", "head", "noscript":
+func newTextWriter(w io.Writer) *textWriter {
+	return &textWriter{w: bufio.NewWriter(w)}
+}
+
+func (t *textWriter) WriteString(s string) {
+	for {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			t.line.WriteString(s)
 			return
-		case "br":
-			w.WriteString("\n")
-		case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6",
-			"li", "tr", "blockquote", "pre", "table":
-			w.WriteString("\n")
 		}
+		t.line.WriteString(s[:i])
+		t.endLine()
+		s = s[i+1:]
 	}
+}
 
-	if n.Type == html.TextNode {
-		text := strings.TrimSpace(n.Data)
-		if text != "" {
-			w.WriteString(text)
-			w.WriteString(" ")
+func (t *textWriter) endLine() {
+	trimmed := strings.TrimSpace(t.line.String())
+	t.line.Reset()
+	if trimmed == "" {
+		t.blank = t.started
+		return
+	}
+	if t.started {
+		t.w.WriteString("\n")
+		if t.blank {
+			t.w.WriteString("\n")
 		}
 	}
-
-	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		extractText(c, w)
+	if _, err := t.w.WriteString(trimmed); err != nil && t.err == nil {
+		t.err = err
 	}
+	t.started, t.blank = true, false
+}
 
-	if n.Type == html.ElementNode {
-		switch strings.ToLower(n.Data) {
-		case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6",
-			"li", "tr", "blockquote", "pre", "table":
-			w.WriteString("\n")
-		}
+// Flush writes the last line and flushes the underlying writer.
+func (t *textWriter) Flush() error {
+	t.endLine()
+	if err := t.w.Flush(); err != nil && t.err == nil {
+		t.err = err
 	}
+	return t.err
 }
 
 // normalizeWhitespace collapses sequences of blank lines into at most two newlines.
 func normalizeWhitespace(s string) string {
-	lines := strings.Split(s, "\n")
-	var result []string
-	blankCount := 0
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed == "" {
-			blankCount++
-			if blankCount <= 1 {
-				result = append(result, "")
-			}
-		} else {
-			blankCount = 0
-			result = append(result, trimmed)
-		}
-	}
-	return strings.Join(result, "\n")
+	var sb strings.Builder
+	t := newTextWriter(&sb)
+	t.WriteString(s)
+	t.Flush()
+	return sb.String()
 }
 
 // stripTagsSimple is a basic fallback HTML tag stripper.
@@ -1,6 +1,7 @@
 package format
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -99,3 +100,41 @@ func TestStripTagsSimple(t *testing.T) {
 		t.Errorf("stripTagsSimple should preserve text, got: %q", got)
 	}
 }
+
+func TestWriteHTMLText_MatchesHTMLToText(t *testing.T) {
+	inputs := []string{
+		"<html><head><title>T</title></head><body><h1>§ 1</h1><p>Absatz&nbsp;1 &amp; 2</p><p></p><p></p><table><tr><td>a</td><td>b</td></tr></table></body></html>",
+		"<title>Ohne head</title><div>Text<br>weiter</div>",
+		"  <p>  A  </p>\n\n\n<p>B</p>  ",
+	}
+	for _, in := range inputs {
+		var sb strings.Builder
+		if err := WriteHTMLText(&sb, strings.NewReader(in)); err != nil {
+			t.Fatalf("WriteHTMLText: %v", err)
+		}
+		if got, want := sb.String(), HTMLToText(in); got != want {
+			t.Errorf("WriteHTMLText(%q) = %q, HTMLToText = %q", in, got, want)
+		}
+	}
+
+	got := HTMLToText("<title>Ohne head</title><div>Text<br>weiter</div>")
+	if got != "Text\nweiter" {
+		t.Errorf("HTMLToText() = %q, want %q", got, "Text\nweiter")
+	}
+}
+
+func TestWriteHTMLText_StopsOnWriteError(t *testing.T) {
+	errClosed := errors.New("closed")
+	in := strings.NewReader(strings.Repeat("<p>Absatz</p>", 10000))
+	err := WriteHTMLText(failingWriter{errClosed}, in)
+	if !errors.Is(err, errClosed) {
+		t.Fatalf("expected write error, got %v", err)
+	}
+	if in.Len() == 0 {
+		t.Error("WriteHTMLText should stop reading after a write error")
+	}
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
@@ -0,0 +1,248 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/philrox/risgo/internal/model"
+)
+
+// SearchStream writes search results incrementally, e.g. while further
+// pages are still being fetched. Begin is called once before the first
+// document and End once after the last.
+type SearchStream interface {
+	Begin(totalHits int) error
+	Document(doc model.Document) error
+	End(summary StreamSummary) error
+}
+
+// StreamSummary describes how a streamed result list ended.
+type StreamSummary struct {
+	// HasMore reports that results were left out because of a result cap.
+	HasMore bool
+	// FailedPages lists pages that could not be fetched; their documents are missing.
+	FailedPages []int
+}
+
+// NewTextStream returns a SearchStream writing human-readable text,
+// numbering documents continuously across pages.
+func NewTextStream(w io.Writer) SearchStream {
+	return &textStream{w: w}
+}
+
+type textStream struct {
+	w         io.Writer
+	totalHits int
+	n         int
+}
+
+func (s *textStream) Begin(totalHits int) error {
+	s.totalHits = totalHits
+	return nil
+}
+
+func (s *textStream) Document(doc model.Document) error {
+	if s.n == 0 {
+		fmt.Fprintln(s.w, bold(fmt.Sprintf("Ergebnisse: %d gesamt (alle Seiten)", s.totalHits)))
+		fmt.Fprintln(s.w, dim(strings.Repeat("─", separatorWidth)))
+	}
+	s.n++
+	writeTextEntry(s.w, s.n, doc)
+	return nil
+}
+
+func (s *textStream) End(summary StreamSummary) error {
+	if s.n == 0 && len(summary.FailedPages) == 0 {
+		fmt.Fprintln(s.w, "Keine Ergebnisse gefunden.")
+		return nil
+	}
+	fmt.Fprintln(s.w)
+	if summary.HasMore {
+		fmt.Fprintln(s.w, boldYellow(fmt.Sprintf("Ausgabe nach %d von %d Ergebnissen begrenzt (--max-results).", s.n, s.totalHits)))
+	}
+	if len(summary.FailedPages) > 0 {
+		fmt.Fprintln(s.w, boldYellow(fmt.Sprintf("Unvollständig: Seite(n) %s konnten nicht geladen werden.", JoinInts(summary.FailedPages))))
+	}
+	return nil
+}
+
+// JoinInts formats numbers as a comma-separated list, e.g. "3, 7".
+func JoinInts(nums []int) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// NewJSONStream returns a SearchStream writing a single JSON object of the form
+// {"total_hits": N, "documents": [...], "has_more": false, "failed_pages": []}.
+// Documents are written as they arrive, so the output is only valid JSON after End.
+func NewJSONStream(w io.Writer) SearchStream {
+	return &jsonStream{w: w}
+}
+
+type jsonStream struct {
+	w io.Writer
+	n int
+}
+
+func (s *jsonStream) Begin(totalHits int) error {
+	_, err := fmt.Fprintf(s.w, "{\n  \"total_hits\": %d,\n  \"documents\": [", totalHits)
+	return err
+}
+
+func (s *jsonStream) Document(doc model.Document) error {
+	data, err := json.MarshalIndent(doc, "    ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	sep := ","
+	if s.n == 0 {
+		sep = ""
+	}
+	s.n++
+	_, err = fmt.Fprintf(s.w, "%s\n    %s", sep, data)
+	return err
+}
+
+func (s *jsonStream) End(summary StreamSummary) error {
+	closing := "\n  ]"
+	if s.n == 0 {
+		closing = "]"
+	}
+	failedPages := summary.FailedPages
+	if failedPages == nil {
+		failedPages = []int{}
+	}
+	failed, err := json.Marshal(failedPages)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	_, err = fmt.Fprintf(s.w, "%s,\n  \"has_more\": %t,\n  \"failed_pages\": %s\n}\n", closing, summary.HasMore, failed)
+	return err
+}
+
+// DocumentStream writes the documents of a batch (e.g. "risgo dokument A B C")
+// one after another as they are fetched. Documents that could not be fetched
+// are reported in place with Failed; End is called once after the last.
+type DocumentStream interface {
+	Document(doc model.Document, content string) error
+	// Failed reports a document that could not be fetched. In JSON, err is
+	// written as its json.Marshaler encoding if it has one.
+	Failed(dokumentnummer string, err error) error
+	End() error
+}
+
+// NewTextDocumentStream returns a DocumentStream writing each document as
+// TextDocument does, separated by a horizontal rule.
+func NewTextDocumentStream(w io.Writer) DocumentStream {
+	return &textDocumentStream{w: w}
+}
+
+type textDocumentStream struct {
+	w      io.Writer
+	n      int
+	failed int
+}
+
+func (s *textDocumentStream) separate() {
+	if s.n > 0 {
+		fmt.Fprintln(s.w)
+		fmt.Fprintln(s.w, dim(strings.Repeat("═", separatorWidth)))
+		fmt.Fprintln(s.w)
+	}
+	s.n++
+}
+
+func (s *textDocumentStream) Document(doc model.Document, content string) error {
+	s.separate()
+	return TextDocument(s.w, doc, content)
+}
+
+func (s *textDocumentStream) Failed(dokumentnummer string, err error) error {
+	s.separate()
+	s.failed++
+	_, werr := fmt.Fprintf(s.w, "%s %s\n%s\n", boldYellow("Fehler:"), cyan(dokumentnummer), strings.TrimPrefix(err.Error(), "Fehler: "))
+	return werr
+}
+
+func (s *textDocumentStream) End() error {
+	if s.failed > 0 {
+		fmt.Fprintln(s.w)
+		fmt.Fprintln(s.w, boldYellow(fmt.Sprintf("Unvollständig: %d von %d Dokumenten konnten nicht abgerufen werden.", s.failed, s.n)))
+	}
+	return nil
+}
+
+// NewJSONDocumentStream returns a DocumentStream writing a JSON array of
+// {"metadata": ..., "content": ...} objects as JSONDocument does, with
+// {"dokumentnummer": ..., "error": ...} for failed documents. With ndjson set
+// it writes one compact object per line instead of an array.
+func NewJSONDocumentStream(w io.Writer, ndjson bool) DocumentStream {
+	return &jsonDocumentStream{w: w, ndjson: ndjson}
+}
+
+type jsonDocumentStream struct {
+	w      io.Writer
+	ndjson bool
+	n      int
+}
+
+// failedDocument is the JSON form of a document that could not be fetched.
+type failedDocument struct {
+	Dokumentnummer string `json:"dokumentnummer"`
+	Error          any    `json:"error"`
+}
+
+func (s *jsonDocumentStream) write(v any) error {
+	if s.ndjson {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		_, err = fmt.Fprintf(s.w, "%s\n", data)
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	sep := ","
+	if s.n == 0 {
+		sep = "["
+	}
+	s.n++
+	_, err = fmt.Fprintf(s.w, "%s\n  %s", sep, data)
+	return err
+}
+
+func (s *jsonDocumentStream) Document(doc model.Document, content string) error {
+	return s.write(model.DocumentContent{Metadata: doc, Content: content})
+}
+
+func (s *jsonDocumentStream) Failed(dokumentnummer string, err error) error {
+	var info any = struct {
+		Message string `json:"message"`
+	}{err.Error()}
+	if m, ok := err.(json.Marshaler); ok {
+		info = m
+	}
+	return s.write(failedDocument{Dokumentnummer: dokumentnummer, Error: info})
+}
+
+func (s *jsonDocumentStream) End() error {
+	if s.ndjson {
+		return nil
+	}
+	closing := "\n]\n"
+	if s.n == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(s.w, closing)
+	return err
+}
@@ -0,0 +1,135 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/philrox/risgo/internal/model"
+)
+
+func TestJSONStream_ValidJSON(t *testing.T) {
+	for _, n := range []int{0, 1, 3} {
+		var buf bytes.Buffer
+		s := NewJSONStream(&buf)
+		if err := s.Begin(42); err != nil {
+			t.Fatal(err)
+		}
+		for i := range n {
+			if err := s.Document(model.Document{Dokumentnummer: "NOR" + strings.Repeat("1", i+5)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.End(StreamSummary{HasMore: true, FailedPages: []int{3}}); err != nil {
+			t.Fatal(err)
+		}
+
+		var parsed struct {
+			TotalHits   int              `json:"total_hits"`
+			Documents   []model.Document `json:"documents"`
+			HasMore     bool             `json:"has_more"`
+			FailedPages []int            `json:"failed_pages"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+			t.Fatalf("%d documents: output is not valid JSON: %v\n%s", n, err, buf.String())
+		}
+		if parsed.TotalHits != 42 || len(parsed.Documents) != n || !parsed.HasMore || len(parsed.FailedPages) != 1 {
+			t.Errorf("%d documents: parsed = %+v", n, parsed)
+		}
+	}
+}
+
+func TestTextStream_ContinuousNumbering(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewTextStream(&buf)
+	s.Begin(30)
+	for range 3 {
+		s.Document(model.Document{Titel: "§ 1"})
+	}
+	s.End(StreamSummary{HasMore: true, FailedPages: []int{2, 5}})
+
+	out := buf.String()
+	for _, want := range []string{"Ergebnisse: 30 gesamt", "[1] § 1", "[3] § 1", "nach 3 von 30 Ergebnissen begrenzt", "Seite(n) 2, 5"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTextStream_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewTextStream(&buf)
+	s.Begin(0)
+	s.End(StreamSummary{})
+	if !strings.Contains(buf.String(), "Keine Ergebnisse gefunden.") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestJSONDocumentStream_ValidJSON(t *testing.T) {
+	for _, n := range []int{0, 1, 3} {
+		var buf bytes.Buffer
+		s := NewJSONDocumentStream(&buf, false)
+		for i := range n {
+			if err := s.Document(model.Document{Dokumentnummer: "NOR" + strings.Repeat("1", i+5)}, "Text"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.Failed("NOR99999", errors.New("nicht gefunden")); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.End(); err != nil {
+			t.Fatal(err)
+		}
+
+		var parsed []struct {
+			Metadata       *model.Document `json:"metadata"`
+			Content        string          `json:"content"`
+			Dokumentnummer string          `json:"dokumentnummer"`
+			Error          struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+			t.Fatalf("%d documents: output is not valid JSON: %v\n%s", n, err, buf.String())
+		}
+		if len(parsed) != n+1 || parsed[n].Dokumentnummer != "NOR99999" || parsed[n].Error.Message != "nicht gefunden" {
+			t.Errorf("%d documents: parsed = %+v", n, parsed)
+		}
+	}
+}
+
+func TestJSONDocumentStream_NDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewJSONDocumentStream(&buf, true)
+	s.Document(model.Document{Dokumentnummer: "NOR11111"}, "Text")
+	s.Failed("NOR22222", errors.New("nicht gefunden"))
+	s.End()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Errorf("line is not valid JSON: %s", line)
+		}
+	}
+}
+
+func TestTextDocumentStream_ReportsFailures(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewTextDocumentStream(&buf)
+	s.Document(model.Document{Titel: "§ 1 ABGB"}, "Inhalt")
+	s.Failed("NOR22222", errors.New("Fehler: Dokument nicht gefunden"))
+	s.End()
+
+	out := buf.String()
+	for _, want := range []string{"§ 1 ABGB", "Inhalt", "NOR22222", "Dokument nicht gefunden", "1 von 2 Dokumenten"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
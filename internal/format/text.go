@@ -25,6 +25,8 @@ const (
 	separatorWidth = 60
 	// maxLeitsatzPreview is the maximum character length for Leitsatz previews in search results.
 	maxLeitsatzPreview = 200
+	// maxAenderungenPreview is the number of amendments listed per law in search results.
+	maxAenderungenPreview = 3
 )
 
 // Text writes search results as human-readable text to the writer.
@@ -40,50 +42,83 @@ func Text(w io.Writer, result model.SearchResult) error {
 	fmt.Fprintln(w, dim(strings.Repeat("─", separatorWidth)))
 
 	for i, doc := range result.Documents {
-		fmt.Fprintf(w, "\n[%d] %s\n", i+1, boldWhite(docTitle(doc)))
+		writeTextEntry(w, i+1, doc)
+	}
 
-		if doc.Dokumentnummer != "" {
-			fmt.Fprintf(w, "    Nr: %s\n", cyan(doc.Dokumentnummer))
-		}
+	fmt.Fprintln(w)
+	if result.HasMore {
+		nextPage := result.Page + 1
+		fmt.Fprintln(w, boldYellow(fmt.Sprintf("Weitere Ergebnisse verfügbar. Nächste Seite: --page %d", nextPage)))
+	}
 
-		citation := FormatCitation(doc.Citation)
-		if citation != "" {
-			fmt.Fprintf(w, "    Zitat: %s\n", citation)
-		}
+	return nil
+}
 
-		if doc.Geschaeftszahl != "" {
-			fmt.Fprintf(w, "    GZ: %s\n", green(doc.Geschaeftszahl))
-		}
+// writeTextEntry writes the n-th search hit of a result list.
+func writeTextEntry(w io.Writer, n int, doc model.Document) {
+	fmt.Fprintf(w, "\n[%d] %s\n", n, boldWhite(docTitle(doc)))
 
-		dates := FormatDates(doc.Citation)
-		if dates != "" {
-			fmt.Fprintf(w, "    Geltung: %s\n", dim(dates))
-		}
+	if doc.Dokumentnummer != "" {
+		fmt.Fprintf(w, "    Nr: %s\n", cyan(doc.Dokumentnummer))
+	}
 
-		if doc.Citation != nil && doc.Citation.Eli != "" {
-			fmt.Fprintf(w, "    ELI: %s\n", dim(doc.Citation.Eli))
-		}
+	citation := FormatCitation(doc.Citation)
+	if citation != "" {
+		fmt.Fprintf(w, "    Zitat: %s\n", citation)
+	}
 
-		if doc.Leitsatz != "" {
-			leitsatz := doc.Leitsatz
-			if len(leitsatz) > maxLeitsatzPreview {
-				leitsatz = leitsatz[:maxLeitsatzPreview] + "..."
-			}
-			fmt.Fprintf(w, "    Leitsatz: %s\n", leitsatz)
-		}
+	if gz := geschaeftszahlen(doc); gz != "" {
+		fmt.Fprintf(w, "    GZ: %s\n", green(gz))
 	}
 
-	fmt.Fprintln(w)
-	if result.HasMore {
-		nextPage := result.Page + 1
-		fmt.Fprintln(w, boldYellow(fmt.Sprintf("Weitere Ergebnisse verfügbar. Nächste Seite: --page %d", nextPage)))
+	dates := FormatDates(doc.Citation)
+	if dates != "" {
+		fmt.Fprintf(w, "    Geltung: %s\n", dim(dates))
 	}
 
-	return nil
+	if doc.Citation != nil && doc.Citation.Eli != "" {
+		fmt.Fprintf(w, "    ELI: %s\n", dim(doc.Citation.Eli))
+	}
+
+	writeLawFields(w, "    ", doc, maxAenderungenPreview)
+	writeJudikaturFields(w, "    ", doc)
+	writeSonstigeFields(w, "    ", doc)
+
+	if doc.Leitsatz != "" {
+		leitsatz := doc.Leitsatz
+		if len(leitsatz) > maxLeitsatzPreview {
+			leitsatz = leitsatz[:maxLeitsatzPreview] + "..."
+		}
+		fmt.Fprintf(w, "    Leitsatz: %s\n", leitsatz)
+	}
 }
 
 // TextDocument writes a single document with its content as human-readable text.
 func TextDocument(w io.Writer, doc model.Document, content string) error {
+	writeDocumentHeader(w, doc)
+
+	if content != "" {
+		writeContentSeparator(w)
+		fmt.Fprintln(w, content)
+	}
+
+	return nil
+}
+
+// TextDocumentHTML is like TextDocument but converts the HTML content read
+// from r to text as it arrives, so long documents start printing at once.
+func TextDocumentHTML(w io.Writer, doc model.Document, r io.Reader) error {
+	writeDocumentHeader(w, doc)
+	writeContentSeparator(w)
+	if err := WriteHTMLText(w, r); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// writeDocumentHeader writes the title and metadata block of TextDocument.
+func writeDocumentHeader(w io.Writer, doc model.Document) {
 	title := docTitle(doc)
 	fmt.Fprintln(w, bold(title))
 	fmt.Fprintln(w, dim(strings.Repeat("═", len(title))))
@@ -103,18 +138,143 @@ func TextDocument(w io.Writer, doc model.Document, content string) error {
 		fmt.Fprintf(w, "Geltung: %s\n", dim(dates))
 	}
 
+	if gz := geschaeftszahlen(doc); gz != "" {
+		fmt.Fprintf(w, "GZ: %s\n", green(gz))
+	}
+
 	if doc.Citation != nil && doc.Citation.Eli != "" {
 		fmt.Fprintf(w, "ELI: %s\n", dim(doc.Citation.Eli))
 	}
 
-	if content != "" {
-		fmt.Fprintln(w)
-		fmt.Fprintln(w, dim(strings.Repeat("─", separatorWidth)))
-		fmt.Fprintln(w)
-		fmt.Fprintln(w, content)
+	writeLawFields(w, "", doc, 0)
+	writeJudikaturFields(w, "", doc)
+	writeSonstigeFields(w, "", doc)
+
+	if doc.Leitsatz != "" {
+		fmt.Fprintf(w, "Leitsatz: %s\n", doc.Leitsatz)
 	}
+}
 
-	return nil
+// geschaeftszahlen returns all case numbers of a decision, separated by
+// semicolons.
+func geschaeftszahlen(doc model.Document) string {
+	if len(doc.Geschaeftszahlen) > 1 {
+		return strings.Join(doc.Geschaeftszahlen, "; ")
+	}
+	return doc.Geschaeftszahl
+}
+
+// writeLawFields writes the metadata specific to consolidated law, each line
+// prefixed with indent. Only the last maxAenderungen amendments are listed
+// (0 = all).
+func writeLawFields(w io.Writer, indent string, doc model.Document, maxAenderungen int) {
+	if doc.Gesetzesnummer != "" {
+		fmt.Fprintf(w, "%sGesetzesnummer: %s\n", indent, doc.Gesetzesnummer)
+	}
+	if doc.Normtyp != "" {
+		fmt.Fprintf(w, "%sTyp: %s\n", indent, doc.Normtyp)
+	}
+	if len(doc.Indizes) > 0 {
+		fmt.Fprintf(w, "%sIndex: %s\n", indent, strings.Join(doc.Indizes, "; "))
+	}
+	if doc.Citation != nil && doc.Citation.Unterzeichnungsdatum != "" {
+		fmt.Fprintf(w, "%sUnterzeichnet: %s\n", indent, dim(doc.Citation.Unterzeichnungsdatum))
+	}
+	if n := len(doc.Aenderungen); n > 0 {
+		list := doc.Aenderungen
+		if maxAenderungen > 0 && n > maxAenderungen {
+			list = list[n-maxAenderungen:]
+		}
+		text := strings.Join(list, "; ")
+		if len(list) < n {
+			text = fmt.Sprintf("%s (zuletzt, %d gesamt)", text, n)
+		}
+		fmt.Fprintf(w, "%sÄnderungen: %s\n", indent, dim(text))
+	}
+}
+
+// writeJudikaturFields writes the metadata specific to court decisions,
+// each line prefixed with indent.
+func writeJudikaturFields(w io.Writer, indent string, doc model.Document) {
+	typ := doc.Dokumenttyp
+	if doc.Entscheidungsart != "" {
+		typ = strings.TrimPrefix(typ+" · "+doc.Entscheidungsart, " · ")
+	}
+	if typ != "" {
+		fmt.Fprintf(w, "%sTyp: %s\n", indent, typ)
+	}
+	if doc.Citation != nil && doc.Citation.Ecli != "" {
+		fmt.Fprintf(w, "%sECLI: %s\n", indent, dim(doc.Citation.Ecli))
+	}
+	if len(doc.Normen) > 0 {
+		fmt.Fprintf(w, "%sNormen: %s\n", indent, strings.Join(doc.Normen, "; "))
+	}
+	if len(doc.Rechtssatznummern) > 0 {
+		fmt.Fprintf(w, "%sRechtssätze: %s\n", indent, cyan(strings.Join(doc.Rechtssatznummern, ", ")))
+	}
+	if len(doc.Schlagworte) > 0 {
+		fmt.Fprintf(w, "%sSchlagworte: %s\n", indent, dim(strings.Join(doc.Schlagworte, "; ")))
+	}
+}
+
+// writeSonstigeFields writes the metadata of the Sonstige applications and
+// of district and municipal law, each line prefixed with indent.
+func writeSonstigeFields(w io.Writer, indent string, doc model.Document) {
+	var session []string
+	if doc.Sitzungsnummer != "" {
+		session = append(session, "Nr. "+doc.Sitzungsnummer)
+	}
+	if doc.Sitzungsdatum != "" {
+		session = append(session, "vom "+doc.Sitzungsdatum)
+	}
+	if doc.Gesetzgebungsperiode != "" {
+		session = append(session, "("+doc.Gesetzgebungsperiode+". GP)")
+	}
+
+	ministry := doc.Bundesministerium
+	if doc.Abteilung != "" {
+		ministry = strings.TrimPrefix(ministry+", "+doc.Abteilung, ", ")
+	}
+
+	fields := []struct{ label, value string }{
+		{"Typ", doc.Typ},
+		{"Sitzung", strings.Join(session, " ")},
+		{"Einbringer", doc.Einbringer},
+		{"Ministerium", ministry},
+		{"Fundstelle", doc.Fundstelle},
+		{"Partei", doc.Partei},
+		{"Urheber", doc.Urheber},
+		{nummerLabel(doc.Applikation), doc.Nummer},
+		{"Behörde", doc.Behoerde},
+		{"Gemeinde", doc.Gemeinde},
+		{"Bezirk", doc.Bezirk},
+		{"Bundesland", doc.Bundesland},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			fmt.Fprintf(w, "%s%s: %s\n", indent, f.label, f.value)
+		}
+	}
+}
+
+// nummerLabel names the number of an announcement by its application.
+func nummerLabel(applikation string) string {
+	switch strings.ToLower(applikation) {
+	case "avsv":
+		return "AVSV-Nr."
+	case "avn":
+		return "AVN-Nr."
+	case "spg":
+		return "SPG-Nr."
+	}
+	return "Nummer"
+}
+
+// writeContentSeparator separates the metadata block from the content.
+func writeContentSeparator(w io.Writer) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, dim(strings.Repeat("─", separatorWidth)))
+	fmt.Fprintln(w)
 }
 
 func docTitle(doc model.Document) string {
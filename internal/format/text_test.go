@@ -221,6 +221,26 @@ func TestTextDocument_EmptyContent(t *testing.T) {
 	}
 }
 
+func TestTextDocumentHTML_StreamsContentAfterMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	doc := model.Document{
+		Dokumentnummer: "JJR_20200101_OGH0002_0010OB00001_20A0000_001",
+		Kurztitel:      "1 Ob 1/20a",
+		Leitsatz:       "Ein Leitsatz.",
+	}
+
+	if err := TextDocumentHTML(&buf, doc, strings.NewReader("<p>Volltext</p>")); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	leitsatz := strings.Index(out, "Leitsatz: Ein Leitsatz.")
+	content := strings.Index(out, "Volltext")
+	if leitsatz < 0 || content < 0 || leitsatz > content {
+		t.Errorf("expected Leitsatz before content, got:\n%s", out)
+	}
+}
+
 func TestText_LeitsatzTruncationBoundary(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -294,3 +314,130 @@ func TestDocTitle_Fallbacks(t *testing.T) {
 		})
 	}
 }
+
+func TestText_JudikaturFields(t *testing.T) {
+	var buf bytes.Buffer
+	result := model.SearchResult{
+		TotalHits: 1,
+		Documents: []model.Document{{
+			Dokumentnummer:    "JJR_20240115_OGH0002_0010OB00001_24A0000_001",
+			Geschaeftszahl:    "1Ob1/24a",
+			Geschaeftszahlen:  []string{"1Ob1/24a", "1Ob2/24b"},
+			Dokumenttyp:       "Rechtssatz",
+			Normen:            []string{"ABGB §1096", "MRG §3"},
+			Schlagworte:       []string{"Mietzinsminderung"},
+			Rechtssatznummern: []string{"RS0021187"},
+			Citation: &model.Citation{
+				Gericht:        "OGH",
+				Geschaeftszahl: "1Ob1/24a",
+				Ecli:           "ECLI:AT:OGH0002:2024:0010OB00001.24A.0115.000",
+			},
+		}},
+	}
+
+	if err := Text(&buf, result); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, check := range []string{
+		"1Ob1/24a; 1Ob2/24b",
+		"Rechtssatz",
+		"ECLI:AT:OGH0002:2024:0010OB00001.24A.0115.000",
+		"ABGB §1096; MRG §3",
+		"RS0021187",
+		"Mietzinsminderung",
+	} {
+		if !strings.Contains(out, check) {
+			t.Errorf("output missing %q\n%s", check, out)
+		}
+	}
+}
+
+func TestText_SonstigeFields(t *testing.T) {
+	var buf bytes.Buffer
+	result := model.SearchResult{
+		TotalHits: 3,
+		Documents: []model.Document{
+			{
+				Dokumentnummer:       "MRP_20240110_01",
+				Applikation:          "Mrp",
+				Titel:                "Ministerrat vom 10. Jänner 2024",
+				Sitzungsnummer:       "1",
+				Sitzungsdatum:        "2024-01-10",
+				Gesetzgebungsperiode: "27",
+			},
+			{
+				Dokumentnummer: "AVSV_2024_0012",
+				Applikation:    "Avsv",
+				Titel:          "Änderung der Satzung",
+				Typ:            "Satzung",
+				Urheber:        "ÖGK",
+				Nummer:         "12/2024",
+				Citation:       &model.Citation{Kundmachungsdatum: "2024-02-01"},
+			},
+			{
+				Dokumentnummer: "BVB_NO_20240101_123",
+				Applikation:    "Bvb",
+				Titel:          "Halteverbot",
+				Behoerde:       "BH Mödling",
+				Bundesland:     "Niederösterreich",
+			},
+		},
+	}
+
+	if err := Text(&buf, result); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, check := range []string{
+		"Sitzung: Nr. 1 vom 2024-01-10 (27. GP)",
+		"Typ: Satzung",
+		"Urheber: ÖGK",
+		"AVSV-Nr.: 12/2024",
+		"vom 2024-02-01",
+		"Behörde: BH Mödling",
+		"Bundesland: Niederösterreich",
+	} {
+		if !strings.Contains(out, check) {
+			t.Errorf("output missing %q\n%s", check, out)
+		}
+	}
+}
+
+func TestText_LawFields(t *testing.T) {
+	var buf bytes.Buffer
+	result := model.SearchResult{
+		TotalHits: 1,
+		Documents: []model.Document{{
+			Dokumentnummer: "NOR40052761",
+			Titel:          "§ 1295",
+			Gesetzesnummer: "10001622",
+			Normtyp:        "BG",
+			Indizes:        []string{"20/01 Allgemeines bürgerliches Recht"},
+			Aenderungen:    []string{"BGBl. Nr. 1/1900", "BGBl. I Nr. 98/2001", "BGBl. I Nr. 87/2015", "BGBl. I Nr. 59/2017"},
+			Citation:       &model.Citation{Kurztitel: "ABGB", Unterzeichnungsdatum: "1811-06-01"},
+		}},
+	}
+
+	if err := Text(&buf, result); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, check := range []string{
+		"Gesetzesnummer: 10001622",
+		"Typ: BG",
+		"Index: 20/01 Allgemeines bürgerliches Recht",
+		"Unterzeichnet: 1811-06-01",
+		"Änderungen: BGBl. I Nr. 98/2001; BGBl. I Nr. 87/2015; BGBl. I Nr. 59/2017 (zuletzt, 4 gesamt)",
+	} {
+		if !strings.Contains(out, check) {
+			t.Errorf("output missing %q\n%s", check, out)
+		}
+	}
+	if strings.Contains(out, "1/1900") {
+		t.Errorf("search entry lists more than %d amendments:\n%s", maxAenderungenPreview, out)
+	}
+}
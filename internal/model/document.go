@@ -12,20 +12,60 @@ type Document struct {
 	GesamteRechtsvorschriftURL string      `json:"gesamte_rechtsvorschrift_url,omitempty"`
 	Geschaeftszahl             string      `json:"geschaeftszahl,omitempty"`
 	Leitsatz                   string      `json:"leitsatz,omitempty"`
+
+	// Consolidated federal and state law. Schlagworte is shared with court
+	// decisions.
+	Gesetzesnummer string   `json:"gesetzesnummer,omitempty"` // e.g. "10001622" for the ABGB
+	Normtyp        string   `json:"normtyp,omitempty"`        // e.g. "BG", "V", "K"
+	Indizes        []string `json:"indizes,omitempty"`        // subject index (Sachgebiet), e.g. "20/01 Allgemeines bürgerliches Recht"
+	Aenderungen    []string `json:"aenderungen,omitempty"`    // amending gazette references, e.g. "BGBl. I Nr. 87/2015"
+
+	// Court decisions (Judikatur).
+	Geschaeftszahlen  []string `json:"geschaeftszahlen,omitempty"`  // all case numbers (also Sonstige, Gemeinden), the first is Geschaeftszahl
+	Dokumenttyp       string   `json:"dokumenttyp,omitempty"`       // "Rechtssatz" or "Entscheidungstext"
+	Entscheidungsart  string   `json:"entscheidungsart,omitempty"`  // e.g. "Erkenntnis", "Beschluss"
+	Normen            []string `json:"normen,omitempty"`            // cited norms, e.g. "ABGB §1096"
+	Schlagworte       []string `json:"schlagworte,omitempty"`       // keywords, also set for laws
+	Rechtssatznummern []string `json:"rechtssatznummern,omitempty"` // headnotes, e.g. "RS0012345"
+
+	// Other publications (Sonstige). Geschaeftszahl and Normen are shared
+	// with court decisions.
+	Typ                  string `json:"typ,omitempty"`                  // kind of publication, e.g. "Satzung", "Erlass"
+	Sitzungsdatum        string `json:"sitzungsdatum,omitempty"`        // Mrp: date of the Council of Ministers session
+	Sitzungsnummer       string `json:"sitzungsnummer,omitempty"`       // Mrp
+	Gesetzgebungsperiode string `json:"gesetzgebungsperiode,omitempty"` // Mrp: legislative period
+	Einbringer           string `json:"einbringer,omitempty"`           // Mrp: submitting ministry
+	Bundesministerium    string `json:"bundesministerium,omitempty"`    // Erlaesse: issuing ministry
+	Abteilung            string `json:"abteilung,omitempty"`            // Erlaesse: department
+	Fundstelle           string `json:"fundstelle,omitempty"`           // Erlaesse: publication reference
+	Partei               string `json:"partei,omitempty"`               // Upts: political party
+	Urheber              string `json:"urheber,omitempty"`              // Avsv: issuing institution
+	Nummer               string `json:"nummer,omitempty"`               // AVSV, AVN or SPG number
+	Bundesland           string `json:"bundesland,omitempty"`           // state of a regional plan (Spg) or a local ordinance
+
+	// District and municipal law (Bezirke, Gemeinden).
+	Behoerde string `json:"behoerde,omitempty"` // issuing district authority, e.g. "BH Mödling"
+	Gemeinde string `json:"gemeinde,omitempty"` // municipality or association of municipalities
+	Bezirk   string `json:"bezirk,omitempty"`   // district of a municipal gazette (GrA)
 }
 
 // Citation contains structured legal citation information.
 type Citation struct {
-	Kurztitel          string  `json:"kurztitel"`
-	Langtitel          string  `json:"langtitel,omitempty"`
-	Kundmachungsorgan  string  `json:"kundmachungsorgan,omitempty"`
-	Paragraph          string  `json:"paragraph,omitempty"`
-	Eli                string  `json:"eli,omitempty"`
-	Inkrafttreten      string  `json:"inkrafttreten,omitempty"`
-	Ausserkrafttreten  *string `json:"ausserkrafttreten"`
-	Geschaeftszahl     string  `json:"geschaeftszahl,omitempty"`
-	Entscheidungsdatum string  `json:"entscheidungsdatum,omitempty"`
-	Leitsatz           string  `json:"leitsatz,omitempty"`
+	Kurztitel            string  `json:"kurztitel"`
+	Langtitel            string  `json:"langtitel,omitempty"`
+	Kundmachungsorgan    string  `json:"kundmachungsorgan,omitempty"`
+	Paragraph            string  `json:"paragraph,omitempty"`
+	Eli                  string  `json:"eli,omitempty"`
+	Inkrafttreten        string  `json:"inkrafttreten,omitempty"`
+	Ausserkrafttreten    *string `json:"ausserkrafttreten"`
+	Geschaeftszahl       string  `json:"geschaeftszahl,omitempty"`
+	Entscheidungsdatum   string  `json:"entscheidungsdatum,omitempty"`
+	Leitsatz             string  `json:"leitsatz,omitempty"`
+	Gericht              string  `json:"gericht,omitempty"`
+	Ecli                 string  `json:"ecli,omitempty"`
+	Unterzeichnungsdatum string  `json:"unterzeichnungsdatum,omitempty"`
+	Kundmachungsnummer   string  `json:"kundmachungsnummer,omitempty"`
+	Kundmachungsdatum    string  `json:"kundmachungsdatum,omitempty"`
 }
 
 // ContentURLs holds URLs for different document formats.
@@ -36,6 +76,22 @@ type ContentURLs struct {
 	RTF  string `json:"rtf"`
 }
 
+// ForType returns the URL for the given data type ("html", "xml", "pdf" or
+// "rtf"), or "" if the document has no such rendition.
+func (c ContentURLs) ForType(dataType string) string {
+	switch dataType {
+	case "html":
+		return c.HTML
+	case "xml":
+		return c.XML
+	case "pdf":
+		return c.PDF
+	case "rtf":
+		return c.RTF
+	}
+	return ""
+}
+
 // DocumentContent represents a full document with its text content.
 type DocumentContent struct {
 	Metadata Document `json:"metadata"`
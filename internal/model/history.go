@@ -0,0 +1,78 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// ChangeType is the kind of change a HistoryEvent reports.
+type ChangeType string
+
+const (
+	ChangeNew     ChangeType = "neu"
+	ChangeUpdated ChangeType = "geaendert"
+	ChangeDeleted ChangeType = "geloescht"
+)
+
+// HistoryEvent is a change of a single document as reported by the History
+// endpoint.
+type HistoryEvent struct {
+	Dokumentnummer string     `json:"dokumentnummer"`
+	Applikation    string     `json:"applikation"`
+	Organ          string     `json:"organ,omitempty"`
+	Titel          string     `json:"titel,omitempty"`
+	Geaendert      string     `json:"geaendert"` // change timestamp, e.g. "2024-06-30T10:15:00"
+	Aenderung      ChangeType `json:"aenderung"`
+	DokumentURL    string     `json:"dokument_url,omitempty"`
+}
+
+// Day returns the date part of the change timestamp (JJJJ-MM-TT).
+func (e HistoryEvent) Day() string {
+	day, _, _ := strings.Cut(e.Geaendert, "T")
+	return day
+}
+
+// Time returns the time of day of the change (HH:MM), or "" if the API
+// reported only a date.
+func (e HistoryEvent) Time() string {
+	_, t, _ := strings.Cut(e.Geaendert, "T")
+	if len(t) > 5 {
+		t = t[:5]
+	}
+	return t
+}
+
+// HistoryResult is a page of change events from the History endpoint.
+type HistoryResult struct {
+	TotalHits int            `json:"total_hits"`
+	Page      int            `json:"page"`
+	PageSize  int            `json:"page_size"`
+	HasMore   bool           `json:"has_more"`
+	Events    []HistoryEvent `json:"events"`
+}
+
+// HistoryDay holds the change events of one day.
+type HistoryDay struct {
+	Datum  string         `json:"datum"`
+	Events []HistoryEvent `json:"events"`
+}
+
+// GroupByDay groups events by the day of their change, newest first. Within
+// a day, events are ordered by time, newest first.
+func GroupByDay(events []HistoryEvent) []HistoryDay {
+	sorted := slices.Clone(events)
+	slices.SortStableFunc(sorted, func(a, b HistoryEvent) int {
+		return cmp.Compare(b.Geaendert, a.Geaendert)
+	})
+
+	var days []HistoryDay
+	for _, e := range sorted {
+		if n := len(days); n > 0 && days[n-1].Datum == e.Day() {
+			days[n-1].Events = append(days[n-1].Events, e)
+			continue
+		}
+		days = append(days, HistoryDay{Datum: e.Day(), Events: []HistoryEvent{e}})
+	}
+	return days
+}
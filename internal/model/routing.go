@@ -1,6 +1,9 @@
 package model
 
-import "strings"
+import (
+	"net/url"
+	"strings"
+)
 
 // DocumentRoute describes how to construct a direct URL or search fallback
 // for a document number based on its prefix.
@@ -72,11 +75,24 @@ func matchPrefix(dokumentnummer string) (DocumentRoute, bool) {
 // DirectURLFromPrefix constructs a direct document URL from a document number
 // using the prefix routing table. Returns empty string if no match found.
 func DirectURLFromPrefix(dokumentnummer string) string {
+	return DirectURLForType(dokumentnummer, "html")
+}
+
+// DirectURLForType is like DirectURLFromPrefix but returns the URL of the
+// given rendition ("html", "xml", "pdf" or "rtf").
+func DirectURLForType(dokumentnummer, dataType string) string {
 	route, ok := matchPrefix(dokumentnummer)
 	if !ok || route.URLPath == "" {
 		return ""
 	}
-	return "https://ris.bka.gv.at/Dokumente/" + route.URLPath + "/" + dokumentnummer + "/" + dokumentnummer + ".html"
+	return "https://ris.bka.gv.at/Dokumente/" + route.URLPath + "/" + dokumentnummer + "/" + dokumentnummer + "." + dataType
+}
+
+// GeltendeFassungURL returns the RIS page listing the current version of
+// all provisions of a law, e.g. GeltendeFassungURL("Bundesnormen", "10001622")
+// for the ABGB.
+func GeltendeFassungURL(abfrage, gesetzesnummer string) string {
+	return "https://ris.bka.gv.at/GeltendeFassung.wxe?Abfrage=" + url.QueryEscape(abfrage) + "&Gesetzesnummer=" + url.QueryEscape(gesetzesnummer)
 }
 
 // SearchFallback returns the endpoint and applikation for search-based document
@@ -249,6 +249,19 @@ func TestDirectURLFromPrefixUnknown(t *testing.T) {
 	}
 }
 
+// TestDirectURLForType verifies that DirectURLForType uses the rendition's
+// file extension.
+func TestDirectURLForType(t *testing.T) {
+	got := DirectURLForType("NOR40026024", "pdf")
+	want := "https://ris.bka.gv.at/Dokumente/Bundesnormen/NOR40026024/NOR40026024.pdf"
+	if got != want {
+		t.Errorf("DirectURLForType(pdf)\n  got  %q\n  want %q", got, want)
+	}
+	if got := DirectURLForType("XYZ_123", "pdf"); got != "" {
+		t.Errorf("DirectURLForType(XYZ_123) = %q, want empty string", got)
+	}
+}
+
 // TestSearchFallbackKnownPrefix verifies that SearchFallback returns the
 // correct endpoint and applikation for known prefixes.
 func TestSearchFallbackKnownPrefix(t *testing.T) {
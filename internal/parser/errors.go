@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// APIError is returned when the RIS API answers a search with an error
+// element instead of results, e.g. for an invalid parameter combination.
+// The API reports these with HTTP 200.
+type APIError struct {
+	// Applikation is the collection the error refers to, if given.
+	Applikation string
+	// Message is the server's error message.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Applikation != "" {
+		return fmt.Sprintf("RIS API-Fehler (%s): %s", e.Applikation, e.Message)
+	}
+	return "RIS API-Fehler: " + e.Message
+}
+
+// IsAPIError reports whether data is a response carrying an error element
+// instead of results (see APIError).
+func IsAPIError(data []byte) bool {
+	var raw struct {
+		OgdSearchResult struct {
+			Error FlexibleArray[rawError] `json:"Error"`
+		} `json:"OgdSearchResult"`
+	}
+	return json.Unmarshal(data, &raw) == nil && apiError(raw.OgdSearchResult.Error) != nil
+}
+
+// rawError is an entry of OgdSearchResult.Error. The API sends either an
+// object with Applikation and Message or a plain message string.
+type rawError struct {
+	Applikation FlexibleString `json:"Applikation"`
+	Message     FlexibleString `json:"Message"`
+}
+
+func (e *rawError) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		e.Message = FlexibleString(s)
+		return nil
+	}
+	type plain rawError
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+// apiError converts the error element of a response into an *APIError,
+// or returns nil if the response contains no error. Multiple messages are
+// joined.
+func apiError(errs []rawError) error {
+	var (
+		applikation string
+		messages    []string
+	)
+	for _, e := range errs {
+		if msg := strings.TrimSpace(e.Message.String()); msg != "" {
+			messages = append(messages, msg)
+		}
+		if applikation == "" {
+			applikation = e.Applikation.String()
+		}
+	}
+	if len(messages) == 0 {
+		if len(errs) == 0 {
+			return nil
+		}
+		messages = []string{"Anfrage abgelehnt (keine Fehlermeldung übermittelt)"}
+	}
+	return &APIError{Applikation: applikation, Message: strings.Join(messages, "; ")}
+}
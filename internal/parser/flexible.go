@@ -91,3 +91,60 @@ func (f *FlexibleInt) UnmarshalJSON(data []byte) error {
 
 	return fmt.Errorf("cannot unmarshal %s into FlexibleInt", string(data))
 }
+
+// FlexibleStrings handles list fields that can be a plain string, a "#text"
+// object, an array of either, or an object whose "item" property holds any
+// of these (e.g. {"item": ["G123/2023", "G124/2023"]}). Empty entries are
+// dropped.
+type FlexibleStrings []string
+
+func (f *FlexibleStrings) UnmarshalJSON(data []byte) error {
+	var obj struct {
+		Item json.RawMessage `json:"item"`
+	}
+	if err := json.Unmarshal(data, &obj); err == nil && obj.Item != nil {
+		data = obj.Item
+	}
+
+	var items FlexibleArray[FlexibleString]
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("cannot unmarshal %s into FlexibleStrings", string(data))
+	}
+	*f = nil
+	for _, item := range items {
+		if item != "" {
+			*f = append(*f, item.String())
+		}
+	}
+	return nil
+}
+
+// First returns the first entry, or "" if there is none.
+func (f FlexibleStrings) First() string {
+	if len(f) == 0 {
+		return ""
+	}
+	return f[0]
+}
+
+// unmarshalLenient decodes the JSON object data into v like json.Unmarshal,
+// but skips members that do not fit v instead of giving up on the whole
+// object, so that one unexpected field does not cost a document all its
+// metadata. It fails only if data is not an object.
+func unmarshalLenient(data []byte, v any) error {
+	if json.Unmarshal(data, v) == nil {
+		return nil
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for name, value := range members {
+		member, err := json.Marshal(map[string]json.RawMessage{name: value})
+		if err != nil {
+			continue
+		}
+		json.Unmarshal(member, v) // a member that does not fit is skipped
+	}
+	return nil
+}
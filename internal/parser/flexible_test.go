@@ -2,6 +2,7 @@ package parser
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
 )
 
@@ -317,3 +318,31 @@ func TestFlexibleIntInStruct(t *testing.T) {
 		})
 	}
 }
+
+// ---------------------------------------------------------------------------
+// FlexibleStrings
+// ---------------------------------------------------------------------------
+
+func TestFlexibleStringsUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{`"ABGB §1096"`, []string{"ABGB §1096"}},
+		{`{"#text": "ABGB §1096"}`, []string{"ABGB §1096"}},
+		{`["a", {"#text": "b"}, ""]`, []string{"a", "b"}},
+		{`{"item": "1Ob1/24a"}`, []string{"1Ob1/24a"}},
+		{`{"item": ["G123/2023", "G124/2023"]}`, []string{"G123/2023", "G124/2023"}},
+		{`""`, nil},
+	}
+	for _, tt := range tests {
+		var got FlexibleStrings
+		if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.input, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Unmarshal(%s) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
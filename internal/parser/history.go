@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"bytes"
+	"cmp"
+	"io"
+	"strings"
+
+	"github.com/philrox/risgo/internal/model"
+)
+
+// ParseHistoryResponse parses the raw JSON response of the History endpoint
+// into change events.
+func ParseHistoryResponse(data []byte) (model.HistoryResult, error) {
+	return DecodeHistoryResponse(bytes.NewReader(data))
+}
+
+// DecodeHistoryResponse decodes a History response read from r. Errors are
+// reported as by DecodeSearchResponse.
+func DecodeHistoryResponse(r io.Reader) (model.HistoryResult, error) {
+	results, err := decodeResults(r)
+	if err != nil {
+		return model.HistoryResult{}, err
+	}
+
+	totalHits, page, pageSize := parseHits(results.Hits)
+
+	var events []model.HistoryEvent
+	for _, ref := range results.Docs {
+		events = append(events, parseHistoryEvent(ref))
+	}
+
+	return model.HistoryResult{
+		TotalHits: totalHits,
+		Page:      page,
+		PageSize:  pageSize,
+		HasMore:   (page * pageSize) < totalHits,
+		Events:    events,
+	}, nil
+}
+
+// parseHistoryEvent converts a document reference of a History response
+// into a change event. Titles come from the domain-specific metadata, if
+// the API sends any.
+func parseHistoryEvent(ref rawDocumentReference) model.HistoryEvent {
+	meta := ref.Data.Metadaten
+	doc := parseDocumentReference(ref)
+
+	return model.HistoryEvent{
+		Dokumentnummer: doc.Dokumentnummer,
+		Applikation:    doc.Applikation,
+		Organ:          meta.Technisch.Organ.String(),
+		Titel:          cmp.Or(doc.Titel, doc.Kurztitel),
+		Geaendert:      meta.Allgemein.Geaendert,
+		Aenderung:      changeType(meta.Allgemein),
+		DokumentURL:    doc.DokumentURL,
+	}
+}
+
+// changeType classifies a change: deleted documents are flagged by the API,
+// documents published on the day of the change are new, all others changed.
+func changeType(a rawAllgemein) model.ChangeType {
+	switch strings.ToLower(a.Geloescht.String()) {
+	case "true", "ja", "1":
+		return model.ChangeDeleted
+	}
+	published, _, _ := strings.Cut(a.Veroeffentlicht, "T")
+	changed, _, _ := strings.Cut(a.Geaendert, "T")
+	if published != "" && published == changed {
+		return model.ChangeNew
+	}
+	return model.ChangeUpdated
+}
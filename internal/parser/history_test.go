@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/philrox/risgo/internal/model"
+)
+
+func TestParseHistoryResponse(t *testing.T) {
+	data := []byte(`{"OgdSearchResult": {"OgdDocumentResults": {
+		"Hits": {"#text": "3", "@pageNumber": "1", "@pageSize": "20"},
+		"OgdDocumentReference": [
+			{"Data": {"Metadaten": {
+				"Technisch": {"ID": "NOR12017681", "Applikation": "Bundesnormen", "Organ": "Bundesrecht konsolidiert"},
+				"Allgemein": {"DokumentUrl": "https://example.com/NOR12017681.html", "Geaendert": "2024-06-30T10:15:00", "Veroeffentlicht": "2011-01-01"},
+				"Bundesrecht": {"Kurztitel": "ABGB", "Titel": "§ 1"}
+			}}},
+			{"Data": {"Metadaten": {
+				"Technisch": {"ID": "NOR12017682", "Applikation": "Bundesnormen"},
+				"Allgemein": {"Geaendert": "2024-07-01T08:02:11", "Veroeffentlicht": "2024-07-01"}
+			}}},
+			{"Data": {"Metadaten": {
+				"Technisch": {"ID": "NOR12017683", "Applikation": "Bundesnormen"},
+				"Allgemein": {"Geaendert": "2024-07-01T09:00:00", "Geloescht": "true"}
+			}}}
+		]
+	}}}`)
+
+	result, err := ParseHistoryResponse(data)
+	if err != nil {
+		t.Fatalf("ParseHistoryResponse returned error: %v", err)
+	}
+	if result.TotalHits != 3 || result.HasMore || len(result.Events) != 3 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	want := []model.HistoryEvent{
+		{
+			Dokumentnummer: "NOR12017681",
+			Applikation:    "Bundesnormen",
+			Organ:          "Bundesrecht konsolidiert",
+			Titel:          "§ 1",
+			Geaendert:      "2024-06-30T10:15:00",
+			Aenderung:      model.ChangeUpdated,
+			DokumentURL:    "https://example.com/NOR12017681.html",
+		},
+		{Dokumentnummer: "NOR12017682", Applikation: "Bundesnormen", Geaendert: "2024-07-01T08:02:11", Aenderung: model.ChangeNew},
+		{Dokumentnummer: "NOR12017683", Applikation: "Bundesnormen", Geaendert: "2024-07-01T09:00:00", Aenderung: model.ChangeDeleted},
+	}
+	for i, e := range result.Events {
+		if e != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, e, want[i])
+		}
+	}
+}
+
+func TestParseHistoryResponse_APIError(t *testing.T) {
+	_, err := ParseHistoryResponse([]byte(`{"OgdSearchResult": {"Error": {"Applikation": "History", "Message": "Anwendung fehlt"}}}`))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+}
@@ -7,9 +7,11 @@ type rawResponse struct {
 	OgdSearchResult rawSearchResult `json:"OgdSearchResult"`
 }
 
-// rawSearchResult contains the document results.
+// rawSearchResult contains the document results, or an error element if
+// the API rejected the query.
 type rawSearchResult struct {
-	OgdDocumentResults rawDocumentResults `json:"OgdDocumentResults"`
+	OgdDocumentResults rawDocumentResults      `json:"OgdDocumentResults"`
+	Error              FlexibleArray[rawError] `json:"Error"`
 }
 
 // rawDocumentResults contains hit count and document references.
@@ -43,15 +45,24 @@ type rawMetadaten struct {
 	Bundesrecht json.RawMessage `json:"Bundesrecht,omitempty"`
 	Landesrecht json.RawMessage `json:"Landesrecht,omitempty"`
 	Judikatur   json.RawMessage `json:"Judikatur,omitempty"`
+	Sonstige    json.RawMessage `json:"Sonstige,omitempty"`
+	Bezirke     json.RawMessage `json:"Bezirke,omitempty"`
+	Gemeinden   json.RawMessage `json:"Gemeinden,omitempty"`
 }
 
 type rawTechnisch struct {
-	ID          string `json:"ID"`
-	Applikation string `json:"Applikation"`
+	ID          string         `json:"ID"`
+	Applikation string         `json:"Applikation"`
+	Organ       FlexibleString `json:"Organ"`
 }
 
+// rawAllgemein holds the general metadata. The change fields are set in
+// History responses.
 type rawAllgemein struct {
-	DokumentURL string `json:"DokumentUrl"`
+	DokumentURL     string         `json:"DokumentUrl"`
+	Geaendert       string         `json:"Geaendert"`
+	Veroeffentlicht string         `json:"Veroeffentlicht"`
+	Geloescht       FlexibleString `json:"Geloescht"`
 }
 
 // rawBundesrecht is the Bundesrecht metadata section.
@@ -71,11 +82,24 @@ type rawBundesrecht struct {
 
 // rawSubApp is a sub-application section for both Bundesrecht and Landesrecht.
 type rawSubApp struct {
-	Kundmachungsorgan          string         `json:"Kundmachungsorgan"`
-	ArtikelParagraphAnlage     FlexibleString `json:"ArtikelParagraphAnlage"`
-	Inkrafttretensdatum        string         `json:"Inkrafttretensdatum"`
-	Ausserkrafttretensdatum    string         `json:"Ausserkrafttretensdatum"`
-	GesamteRechtsvorschriftURL string         `json:"GesamteRechtsvorschriftUrl"`
+	Kundmachungsorgan          string          `json:"Kundmachungsorgan"`
+	ArtikelParagraphAnlage     FlexibleString  `json:"ArtikelParagraphAnlage"`
+	Inkrafttretensdatum        string          `json:"Inkrafttretensdatum"`
+	Ausserkrafttretensdatum    string          `json:"Ausserkrafttretensdatum"`
+	GesamteRechtsvorschriftURL string          `json:"GesamteRechtsvorschriftUrl"`
+	Gesetzesnummer             FlexibleString  `json:"Gesetzesnummer"`
+	Typ                        FlexibleString  `json:"Typ"`
+	Indizes                    FlexibleStrings `json:"Indizes"`
+	Schlagworte                FlexibleStrings `json:"Schlagworte"`
+	Unterzeichnungsdatum       string          `json:"Unterzeichnungsdatum"`
+	Aenderung                  FlexibleStrings `json:"Aenderung"` // amending gazette references
+}
+
+// UnmarshalJSON skips fields of unexpected shape, keeping the rest of the
+// sub-application's section.
+func (s *rawSubApp) UnmarshalJSON(data []byte) error {
+	type plain rawSubApp
+	return unmarshalLenient(data, (*plain)(s))
 }
 
 // rawLandesrecht is the Landesrecht metadata section.
@@ -88,69 +112,167 @@ type rawLandesrecht struct {
 	LgblAuth  *rawSubApp     `json:"LgblAuth,omitempty"`
 	Lgbl      *rawSubApp     `json:"Lgbl,omitempty"`
 	LgblNO    *rawSubApp     `json:"LgblNO,omitempty"`
-	Vbl       *rawSubApp     `json:"Vbl,omitempty"`
+	Vbl       *rawVblApp     `json:"Vbl,omitempty"`
 	Gr        *rawSubApp     `json:"Gr,omitempty"`
 	GrA       *rawSubApp     `json:"GrA,omitempty"`
 }
 
-// rawJudikatur is the Judikatur metadata section.
-type rawJudikatur struct {
-	Kurztitel      string            `json:"Kurztitel"`
-	Langtitel      string            `json:"Langtitel"`
-	Titel          FlexibleString    `json:"Titel"`
-	Geschaeftszahl rawGeschaeftszahl `json:"Geschaeftszahl"`
-	Justiz         *rawJustizApp     `json:"Justiz,omitempty"`
-	Vfgh           *rawJustizApp     `json:"Vfgh,omitempty"`
-	Vwgh           *rawJustizApp     `json:"Vwgh,omitempty"`
-	Bvwg           *rawJustizApp     `json:"Bvwg,omitempty"`
-	Lvwg           *rawJustizApp     `json:"Lvwg,omitempty"`
-	Dsk            *rawJustizApp     `json:"Dsk,omitempty"`
-	Gbk            *rawJustizApp     `json:"Gbk,omitempty"`
-	Pvak           *rawJustizApp     `json:"Pvak,omitempty"`
-	AsylGH         *rawJustizApp     `json:"AsylGH,omitempty"`
-	Dok            *rawJustizApp     `json:"Dok,omitempty"`
-}
-
-// rawGeschaeftszahl handles the polymorphic Geschaeftszahl field.
-type rawGeschaeftszahl struct {
-	Value string
-}
-
-func (g *rawGeschaeftszahl) UnmarshalJSON(data []byte) error {
-	// Try plain string.
-	var s string
-	if err := json.Unmarshal(data, &s); err == nil {
-		g.Value = s
-		return nil
-	}
+// rawVblApp is the Landesrecht sub-application of the state ordinance
+// gazettes (Verordnungsblätter).
+type rawVblApp struct {
+	rawSubApp
+	rawVblFields
+}
 
-	// Try object with item field.
-	var obj struct {
-		Item json.RawMessage `json:"item"`
-	}
-	if err := json.Unmarshal(data, &obj); err == nil && obj.Item != nil {
-		// Item can be string or []string.
-		var itemStr string
-		if err := json.Unmarshal(obj.Item, &itemStr); err == nil {
-			g.Value = itemStr
-			return nil
-		}
-		var items []string
-		if err := json.Unmarshal(obj.Item, &items); err == nil && len(items) > 0 {
-			g.Value = items[0]
-			return nil
-		}
+// rawVblFields are the fields only the Vbl section has.
+type rawVblFields struct {
+	Bundesland         FlexibleString `json:"Bundesland"`
+	Kundmachungsnummer FlexibleString `json:"Kundmachungsnummer"`
+	Kundmachungsdatum  string         `json:"Kundmachungsdatum"`
+}
+
+// UnmarshalJSON decodes both halves leniently. It is needed because the
+// UnmarshalJSON promoted from rawSubApp would skip the Vbl fields.
+func (a *rawVblApp) UnmarshalJSON(data []byte) error {
+	if err := a.rawSubApp.UnmarshalJSON(data); err != nil {
+		return err
 	}
+	return unmarshalLenient(data, &a.rawVblFields)
+}
+
+// rawBezirke is the metadata section of the district authorities
+// (Bezirksverwaltungsbehörden).
+type rawBezirke struct {
+	Kurztitel                  string         `json:"Kurztitel"`
+	Langtitel                  string         `json:"Langtitel"`
+	Titel                      FlexibleString `json:"Titel"`
+	Bundesland                 FlexibleString `json:"Bundesland"`
+	Bezirksverwaltungsbehoerde FlexibleString `json:"Bezirksverwaltungsbehoerde"`
+	Kundmachungsnummer         FlexibleString `json:"Kundmachungsnummer"`
+	Kundmachungsdatum          string         `json:"Kundmachungsdatum"`
+	Inkrafttretensdatum        string         `json:"Inkrafttretensdatum"`
+	Ausserkrafttretensdatum    string         `json:"Ausserkrafttretensdatum"`
+}
 
-	g.Value = ""
-	return nil
+// rawGemeinden is the metadata section of municipal law, both Gr
+// (consolidated) and GrA (municipal gazettes).
+type rawGemeinden struct {
+	Kurztitel               string          `json:"Kurztitel"`
+	Langtitel               string          `json:"Langtitel"`
+	Titel                   FlexibleString  `json:"Titel"`
+	Bundesland              FlexibleString  `json:"Bundesland"`
+	Gemeinde                FlexibleString  `json:"Gemeinde"`
+	Bezirk                  FlexibleString  `json:"Bezirk"`             // GrA
+	Gemeindeverband         FlexibleString  `json:"Gemeindeverband"`    // GrA
+	Geschaeftszahl          FlexibleStrings `json:"Geschaeftszahl"`     // Gr
+	Kundmachungsnummer      FlexibleString  `json:"Kundmachungsnummer"` // GrA
+	Kundmachungsdatum       string          `json:"Kundmachungsdatum"`  // GrA
+	Inkrafttretensdatum     string          `json:"Inkrafttretensdatum"`
+	Ausserkrafttretensdatum string          `json:"Ausserkrafttretensdatum"`
+}
+
+// rawJudikatur is the Judikatur metadata section. Fields describing the
+// decision may appear here or in the sub-application section.
+type rawJudikatur struct {
+	Kurztitel          string          `json:"Kurztitel"`
+	Langtitel          string          `json:"Langtitel"`
+	Titel              FlexibleString  `json:"Titel"`
+	Dokumenttyp        string          `json:"Dokumenttyp"`
+	Geschaeftszahl     FlexibleStrings `json:"Geschaeftszahl"`
+	Normen             FlexibleStrings `json:"Normen"`
+	Schlagworte        FlexibleStrings `json:"Schlagworte"`
+	Entscheidungsdatum string          `json:"Entscheidungsdatum"`
+	Ecli               string          `json:"EuropeanCaseLawIdentifier"`
+	Justiz             *rawJustizApp   `json:"Justiz,omitempty"`
+	Vfgh               *rawJustizApp   `json:"Vfgh,omitempty"`
+	Vwgh               *rawJustizApp   `json:"Vwgh,omitempty"`
+	Bvwg               *rawJustizApp   `json:"Bvwg,omitempty"`
+	Lvwg               *rawJustizApp   `json:"Lvwg,omitempty"`
+	Dsk                *rawJustizApp   `json:"Dsk,omitempty"`
+	Gbk                *rawJustizApp   `json:"Gbk,omitempty"`
+	Pvak               *rawJustizApp   `json:"Pvak,omitempty"`
+	AsylGH             *rawJustizApp   `json:"AsylGH,omitempty"`
+	Dok                *rawJustizApp   `json:"Dok,omitempty"`
 }
 
 // rawJustizApp is a Judikatur sub-application section.
 type rawJustizApp struct {
+	Entscheidungsdatum string          `json:"Entscheidungsdatum"`
+	Leitsatz           FlexibleString  `json:"Leitsatz"`
+	Norm               FlexibleStrings `json:"Norm"`
+	Normen             FlexibleStrings `json:"Normen"`
+	Schlagworte        FlexibleStrings `json:"Schlagworte"`
+	Rechtssatznummern  FlexibleStrings `json:"Rechtssatznummern"`
+	Gericht            string          `json:"Gericht"`
+	Entscheidungsart   string          `json:"Entscheidungsart"`
+	Ecli               string          `json:"EuropeanCaseLawIdentifier"`
+}
+
+// UnmarshalJSON skips fields of unexpected shape, keeping the rest of the
+// court's section.
+func (a *rawJustizApp) UnmarshalJSON(data []byte) error {
+	type plain rawJustizApp
+	return unmarshalLenient(data, (*plain)(a))
+}
+
+// rawSonstige is the Sonstige metadata section.
+type rawSonstige struct {
+	Kurztitel string          `json:"Kurztitel"`
+	Langtitel string          `json:"Langtitel"`
+	Titel     FlexibleString  `json:"Titel"`
+	Mrp       *rawSonstigeApp `json:"Mrp,omitempty"`
+	Erlaesse  *rawSonstigeApp `json:"Erlaesse,omitempty"`
+	Upts      *rawSonstigeApp `json:"Upts,omitempty"`
+	KmGer     *rawSonstigeApp `json:"KmGer,omitempty"`
+	Avsv      *rawSonstigeApp `json:"Avsv,omitempty"`
+	Avn       *rawSonstigeApp `json:"Avn,omitempty"`
+	Spg       *rawSonstigeApp `json:"Spg,omitempty"`
+	PruefGewO *rawSonstigeApp `json:"PruefGewO,omitempty"`
+}
+
+// rawSonstigeApp is a Sonstige sub-application section. Each application
+// fills only its own fields.
+type rawSonstigeApp struct {
+	Geschaeftszahl FlexibleStrings `json:"Geschaeftszahl"` // Mrp, Erlaesse, Upts, KmGer
+	Norm           FlexibleStrings `json:"Norm"`           // Erlaesse, Upts
+
+	// Mrp
+	Sitzungsdatum        string         `json:"Sitzungsdatum"`
+	Sitzungsnummer       FlexibleString `json:"Sitzungsnummer"`
+	Gesetzgebungsperiode FlexibleString `json:"Gesetzgebungsperiode"`
+	Einbringer           FlexibleString `json:"Einbringer"`
+
+	// Erlaesse
+	Bundesministerium       FlexibleString `json:"Bundesministerium"`
+	Abteilung               FlexibleString `json:"Abteilung"`
+	Fundstelle              FlexibleString `json:"Fundstelle"`
+	Veroeffentlicht         string         `json:"Veroeffentlicht"`
+	Inkrafttretensdatum     string         `json:"Inkrafttretensdatum"`
+	Ausserkrafttretensdatum string         `json:"Ausserkrafttretensdatum"`
+
+	// Upts
+	Partei             FlexibleString `json:"Partei"`
 	Entscheidungsdatum string         `json:"Entscheidungsdatum"`
-	Leitsatz           FlexibleString `json:"Leitsatz"`
-	Norm               FlexibleString `json:"Norm"`
+
+	// KmGer, Avsv, Avn, Spg, PruefGewO
+	Kundmachungsdatum string         `json:"Kundmachungsdatum"`
+	Gericht           FlexibleString `json:"Gericht"`
+	Typ               FlexibleString `json:"Typ"`
+	Dokumentart       FlexibleString `json:"Dokumentart"`
+	Urheber           FlexibleString `json:"Urheber"`
+	Avsvnummer        FlexibleString `json:"Avsvnummer"`
+	Avnnummer         FlexibleString `json:"Avnnummer"`
+	Spgnummer         FlexibleString `json:"Spgnummer"`
+	OsgTyp            FlexibleString `json:"OsgTyp"`
+	RsgTyp            FlexibleString `json:"RsgTyp"`
+	RsgLand           FlexibleString `json:"RsgLand"`
+}
+
+// UnmarshalJSON skips fields of unexpected shape, keeping the rest of the
+// application's section.
+func (a *rawSonstigeApp) UnmarshalJSON(data []byte) error {
+	type plain rawSonstigeApp
+	return unmarshalLenient(data, (*plain)(a))
 }
 
 // rawDokumentliste contains content references.
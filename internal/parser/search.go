@@ -1,8 +1,12 @@
 package parser
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 
 	"github.com/philrox/risgo/internal/model"
@@ -13,12 +17,18 @@ const noExpiryDate = "9999-12-31"
 
 // ParseSearchResponse parses the raw JSON API response into a SearchResult.
 func ParseSearchResponse(data []byte) (model.SearchResult, error) {
-	var raw rawResponse
-	if err := json.Unmarshal(data, &raw); err != nil {
-		return model.SearchResult{}, fmt.Errorf("failed to parse API response: %w", err)
-	}
+	return DecodeSearchResponse(bytes.NewReader(data))
+}
 
-	results := raw.OgdSearchResult.OgdDocumentResults
+// DecodeSearchResponse decodes a JSON API response read from r into a
+// SearchResult without first reading the body into a byte slice.
+// Read errors from r are wrapped and can be inspected with errors.As.
+// If the API rejected the query, the error is an *APIError.
+func DecodeSearchResponse(r io.Reader) (model.SearchResult, error) {
+	results, err := decodeResults(r)
+	if err != nil {
+		return model.SearchResult{}, err
+	}
 
 	// Parse hits metadata.
 	totalHits, page, pageSize := parseHits(results.Hits)
@@ -41,6 +51,22 @@ func ParseSearchResponse(data []byte) (model.SearchResult, error) {
 	}, nil
 }
 
+// decodeResults decodes a JSON API response read from r and returns its
+// document results, or an *APIError if the API rejected the query.
+func decodeResults(r io.Reader) (rawDocumentResults, error) {
+	var raw rawResponse
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF // empty body
+		}
+		return rawDocumentResults{}, fmt.Errorf("failed to parse API response: %w", err)
+	}
+	if err := apiError(raw.OgdSearchResult.Error); err != nil {
+		return rawDocumentResults{}, err
+	}
+	return raw.OgdSearchResult.OgdDocumentResults, nil
+}
+
 // parseHits extracts total hits, page number, and page size from the
 // polymorphic Hits field.
 func parseHits(raw json.RawMessage) (totalHits, page, pageSize int) {
@@ -93,6 +119,15 @@ func parseDocumentReference(ref rawDocumentReference) model.Document {
 	if meta.Judikatur != nil {
 		parseJudikatur(meta.Judikatur, &doc)
 	}
+	if meta.Sonstige != nil {
+		parseSonstige(meta.Sonstige, &doc)
+	}
+	if meta.Bezirke != nil {
+		parseBezirke(meta.Bezirke, &doc)
+	}
+	if meta.Gemeinden != nil {
+		parseGemeinden(meta.Gemeinden, &doc)
+	}
 
 	// Parse content URLs from Dokumentliste.
 	if data.Dokumentliste != nil {
@@ -103,8 +138,9 @@ func parseDocumentReference(ref rawDocumentReference) model.Document {
 }
 
 func parseBundesrecht(raw json.RawMessage, doc *model.Document) {
+	// Decoded leniently: a single odd field must not drop the whole section.
 	var br rawBundesrecht
-	if err := json.Unmarshal(raw, &br); err != nil {
+	if err := unmarshalLenient(raw, &br); err != nil {
 		return
 	}
 
@@ -120,14 +156,10 @@ func parseBundesrecht(raw json.RawMessage, doc *model.Document) {
 	// Find the active sub-application section.
 	subApp := firstNonNil(br.BrKons, br.Begut, br.BgblAuth, br.Erv, br.BgblPdf, br.BgblAlt, br.RegV)
 	if subApp != nil {
-		cit.Kundmachungsorgan = subApp.Kundmachungsorgan
-		cit.Paragraph = subApp.ArtikelParagraphAnlage.String()
-		cit.Inkrafttreten = subApp.Inkrafttretensdatum
-		akt := subApp.Ausserkrafttretensdatum
-		if akt != "" && akt != noExpiryDate {
-			cit.Ausserkrafttreten = &akt
-		}
-		doc.GesamteRechtsvorschriftURL = subApp.GesamteRechtsvorschriftURL
+		subApp.apply(doc, cit)
+	}
+	if br.BrKons != nil && doc.GesamteRechtsvorschriftURL == "" && doc.Gesetzesnummer != "" {
+		doc.GesamteRechtsvorschriftURL = model.GeltendeFassungURL("Bundesnormen", doc.Gesetzesnummer)
 	}
 
 	doc.Citation = cit
@@ -135,7 +167,7 @@ func parseBundesrecht(raw json.RawMessage, doc *model.Document) {
 
 func parseLandesrecht(raw json.RawMessage, doc *model.Document) {
 	var lr rawLandesrecht
-	if err := json.Unmarshal(raw, &lr); err != nil {
+	if err := unmarshalLenient(raw, &lr); err != nil {
 		return
 	}
 
@@ -148,59 +180,88 @@ func parseLandesrecht(raw json.RawMessage, doc *model.Document) {
 		Eli:       lr.Eli,
 	}
 
-	subApp := firstNonNil(lr.LrKons, lr.LgblAuth, lr.Lgbl, lr.LgblNO, lr.Vbl, lr.Gr, lr.GrA)
+	var vbl *rawSubApp
+	if lr.Vbl != nil {
+		vbl = &lr.Vbl.rawSubApp
+		doc.Bundesland = lr.Vbl.Bundesland.String()
+		cit.Kundmachungsnummer = lr.Vbl.Kundmachungsnummer.String()
+		cit.Kundmachungsdatum = lr.Vbl.Kundmachungsdatum
+	}
+
+	subApp := firstNonNil(lr.LrKons, lr.LgblAuth, lr.Lgbl, lr.LgblNO, vbl, lr.Gr, lr.GrA)
 	if subApp != nil {
-		cit.Kundmachungsorgan = subApp.Kundmachungsorgan
-		cit.Paragraph = subApp.ArtikelParagraphAnlage.String()
-		cit.Inkrafttreten = subApp.Inkrafttretensdatum
-		akt := subApp.Ausserkrafttretensdatum
-		if akt != "" && akt != noExpiryDate {
-			cit.Ausserkrafttreten = &akt
-		}
-		doc.GesamteRechtsvorschriftURL = subApp.GesamteRechtsvorschriftURL
+		subApp.apply(doc, cit)
 	}
 
 	doc.Citation = cit
 }
 
+// apply copies the fields of a Bundesrecht or Landesrecht sub-application
+// section to doc and cit.
+func (s *rawSubApp) apply(doc *model.Document, cit *model.Citation) {
+	cit.Kundmachungsorgan = s.Kundmachungsorgan
+	cit.Paragraph = s.ArtikelParagraphAnlage.String()
+	cit.Inkrafttreten = s.Inkrafttretensdatum
+	cit.Ausserkrafttreten = expiryDate(s.Ausserkrafttretensdatum)
+	cit.Unterzeichnungsdatum = s.Unterzeichnungsdatum
+	doc.GesamteRechtsvorschriftURL = s.GesamteRechtsvorschriftURL
+	doc.Gesetzesnummer = s.Gesetzesnummer.String()
+	doc.Normtyp = s.Typ.String()
+	doc.Indizes = s.Indizes
+	doc.Schlagworte = s.Schlagworte
+	doc.Aenderungen = s.Aenderung
+}
+
 func parseJudikatur(raw json.RawMessage, doc *model.Document) {
+	// Decoded leniently: a single odd field must not drop the whole section.
 	var jud rawJudikatur
-	if err := json.Unmarshal(raw, &jud); err != nil {
+	if err := unmarshalLenient(raw, &jud); err != nil {
 		return
 	}
 
 	doc.Kurztitel = jud.Kurztitel
 	doc.Titel = jud.Titel.String()
-	doc.Geschaeftszahl = jud.Geschaeftszahl.Value
+	doc.Geschaeftszahl = jud.Geschaeftszahl.First()
+	doc.Geschaeftszahlen = jud.Geschaeftszahl
+	doc.Dokumenttyp = judikaturDokumenttyp(jud.Dokumenttyp)
+	doc.Normen = jud.Normen
+	doc.Schlagworte = jud.Schlagworte
 
 	cit := &model.Citation{
-		Kurztitel:      jud.Kurztitel,
-		Langtitel:      jud.Langtitel,
-		Geschaeftszahl: jud.Geschaeftszahl.Value,
+		Kurztitel:          jud.Kurztitel,
+		Langtitel:          jud.Langtitel,
+		Geschaeftszahl:     jud.Geschaeftszahl.First(),
+		Entscheidungsdatum: jud.Entscheidungsdatum,
+		Inkrafttreten:      jud.Entscheidungsdatum, // Semantic mapping
+		Ecli:               jud.Ecli,
 	}
 
 	// Find active sub-application. Leitsatz only for Vfgh, Vwgh, Justiz, Bvwg.
+	// court names the deciding body where the application implies it.
 	type judApp struct {
 		app         *rawJustizApp
 		hasLeitsatz bool
+		court       string
 	}
 	apps := []judApp{
-		{jud.Vfgh, true},
-		{jud.Vwgh, true},
-		{jud.Justiz, true},
-		{jud.Bvwg, true},
-		{jud.Lvwg, false},
-		{jud.Dsk, false},
-		{jud.Gbk, false},
-		{jud.Pvak, false},
-		{jud.AsylGH, false},
-		{jud.Dok, false},
+		{jud.Vfgh, true, "VfGH"},
+		{jud.Vwgh, true, "VwGH"},
+		{jud.Justiz, true, ""},
+		{jud.Bvwg, true, "BVwG"},
+		{jud.Lvwg, false, ""},
+		{jud.Dsk, false, "DSB"},
+		{jud.Gbk, false, "GBK"},
+		{jud.Pvak, false, "PVAK"},
+		{jud.AsylGH, false, "AsylGH"},
+		{jud.Dok, false, ""},
 	}
 
 	for _, a := range apps {
 		if a.app != nil {
-			cit.Entscheidungsdatum = a.app.Entscheidungsdatum
-			cit.Inkrafttreten = a.app.Entscheidungsdatum // Semantic mapping
+			if a.app.Entscheidungsdatum != "" {
+				cit.Entscheidungsdatum = a.app.Entscheidungsdatum
+				cit.Inkrafttreten = a.app.Entscheidungsdatum
+			}
 			if a.hasLeitsatz {
 				leitsatz := a.app.Leitsatz.String()
 				if leitsatz != "" {
@@ -208,6 +269,16 @@ func parseJudikatur(raw json.RawMessage, doc *model.Document) {
 					doc.Leitsatz = leitsatz
 				}
 			}
+			cit.Gericht = cmp.Or(a.app.Gericht, a.court)
+			cit.Ecli = cmp.Or(a.app.Ecli, cit.Ecli)
+			doc.Entscheidungsart = a.app.Entscheidungsart
+			doc.Rechtssatznummern = a.app.Rechtssatznummern
+			if len(doc.Normen) == 0 {
+				doc.Normen = slices.Concat(a.app.Normen, a.app.Norm)
+			}
+			if len(doc.Schlagworte) == 0 {
+				doc.Schlagworte = a.app.Schlagworte
+			}
 			break
 		}
 	}
@@ -215,6 +286,116 @@ func parseJudikatur(raw json.RawMessage, doc *model.Document) {
 	doc.Citation = cit
 }
 
+// judikaturDokumenttyp names the Judikatur document types: "Rechtssatz"
+// (a headnote) or "Entscheidungstext" (the full decision, "Text" in the API).
+func judikaturDokumenttyp(typ string) string {
+	if strings.EqualFold(typ, "Text") {
+		return "Entscheidungstext"
+	}
+	return typ
+}
+
+func parseSonstige(raw json.RawMessage, doc *model.Document) {
+	// Decoded leniently: a single odd field must not drop the whole section.
+	var so rawSonstige
+	if err := unmarshalLenient(raw, &so); err != nil {
+		return
+	}
+
+	doc.Kurztitel = so.Kurztitel
+	doc.Titel = so.Titel.String()
+
+	cit := &model.Citation{
+		Kurztitel: so.Kurztitel,
+		Langtitel: so.Langtitel,
+	}
+
+	app := firstNonNil(so.Mrp, so.Erlaesse, so.Upts, so.KmGer, so.Avsv, so.Avn, so.Spg, so.PruefGewO)
+	if app != nil {
+		doc.Geschaeftszahl = app.Geschaeftszahl.First()
+		doc.Geschaeftszahlen = app.Geschaeftszahl
+		doc.Normen = app.Norm
+		doc.Typ = cmp.Or(app.Typ, app.Dokumentart, app.OsgTyp, app.RsgTyp).String()
+
+		doc.Sitzungsdatum = app.Sitzungsdatum
+		doc.Sitzungsnummer = app.Sitzungsnummer.String()
+		doc.Gesetzgebungsperiode = app.Gesetzgebungsperiode.String()
+		doc.Einbringer = app.Einbringer.String()
+		doc.Bundesministerium = app.Bundesministerium.String()
+		doc.Abteilung = app.Abteilung.String()
+		doc.Fundstelle = app.Fundstelle.String()
+		doc.Partei = app.Partei.String()
+		doc.Urheber = app.Urheber.String()
+		doc.Nummer = cmp.Or(app.Avsvnummer, app.Avnnummer, app.Spgnummer).String()
+		doc.Bundesland = app.RsgLand.String()
+
+		cit.Geschaeftszahl = doc.Geschaeftszahl
+		cit.Gericht = app.Gericht.String()
+		cit.Entscheidungsdatum = app.Entscheidungsdatum
+		cit.Kundmachungsdatum = cmp.Or(app.Kundmachungsdatum, app.Veroeffentlicht)
+		cit.Inkrafttreten = app.Inkrafttretensdatum
+		cit.Ausserkrafttreten = expiryDate(app.Ausserkrafttretensdatum)
+	}
+
+	doc.Citation = cit
+}
+
+func parseBezirke(raw json.RawMessage, doc *model.Document) {
+	// Decoded leniently: a single odd field must not drop the whole section.
+	var bz rawBezirke
+	if err := unmarshalLenient(raw, &bz); err != nil {
+		return
+	}
+
+	doc.Kurztitel = bz.Kurztitel
+	doc.Titel = bz.Titel.String()
+	doc.Bundesland = bz.Bundesland.String()
+	doc.Behoerde = bz.Bezirksverwaltungsbehoerde.String()
+
+	doc.Citation = &model.Citation{
+		Kurztitel:          bz.Kurztitel,
+		Langtitel:          bz.Langtitel,
+		Kundmachungsnummer: bz.Kundmachungsnummer.String(),
+		Kundmachungsdatum:  bz.Kundmachungsdatum,
+		Inkrafttreten:      bz.Inkrafttretensdatum,
+		Ausserkrafttreten:  expiryDate(bz.Ausserkrafttretensdatum),
+	}
+}
+
+func parseGemeinden(raw json.RawMessage, doc *model.Document) {
+	var gr rawGemeinden
+	if err := unmarshalLenient(raw, &gr); err != nil {
+		return
+	}
+
+	doc.Kurztitel = gr.Kurztitel
+	doc.Titel = gr.Titel.String()
+	doc.Bundesland = gr.Bundesland.String()
+	doc.Gemeinde = cmp.Or(gr.Gemeinde, gr.Gemeindeverband).String()
+	doc.Bezirk = gr.Bezirk.String()
+	doc.Geschaeftszahl = gr.Geschaeftszahl.First()
+	doc.Geschaeftszahlen = gr.Geschaeftszahl
+
+	doc.Citation = &model.Citation{
+		Kurztitel:          gr.Kurztitel,
+		Langtitel:          gr.Langtitel,
+		Geschaeftszahl:     doc.Geschaeftszahl,
+		Kundmachungsnummer: gr.Kundmachungsnummer.String(),
+		Kundmachungsdatum:  gr.Kundmachungsdatum,
+		Inkrafttreten:      gr.Inkrafttretensdatum,
+		Ausserkrafttreten:  expiryDate(gr.Ausserkrafttretensdatum),
+	}
+}
+
+// expiryDate returns a pointer to the expiry date d, or nil if the API
+// reports none.
+func expiryDate(d string) *string {
+	if d == "" || d == noExpiryDate {
+		return nil
+	}
+	return &d
+}
+
 func parseContentURLs(raw json.RawMessage, doc *model.Document) {
 	var dl rawDokumentliste
 	if err := json.Unmarshal(raw, &dl); err != nil {
@@ -240,7 +421,7 @@ func parseContentURLs(raw json.RawMessage, doc *model.Document) {
 	}
 }
 
-func firstNonNil(ptrs ...*rawSubApp) *rawSubApp {
+func firstNonNil[T any](ptrs ...*T) *T {
 	for _, p := range ptrs {
 		if p != nil {
 			return p
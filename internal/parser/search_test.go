@@ -1,8 +1,14 @@
 package parser
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 // buildBundesrechtResponse constructs a minimal rawResponse JSON with
@@ -175,3 +181,414 @@ func TestNoExpiryDate_EmptyStringFiltered(t *testing.T) {
 		t.Errorf("expected Ausserkrafttreten to be nil for empty string, got %q", *doc.Citation.Ausserkrafttreten)
 	}
 }
+
+func TestDecodeSearchResponse_Reader(t *testing.T) {
+	result, err := DecodeSearchResponse(bytes.NewReader(buildBundesrechtResponse("2030-01-01")))
+	if err != nil {
+		t.Fatalf("DecodeSearchResponse returned error: %v", err)
+	}
+	if len(result.Documents) != 1 {
+		t.Fatalf("expected 1 document, got %d", len(result.Documents))
+	}
+
+	readErr := errors.New("connection reset")
+	_, err = DecodeSearchResponse(io.MultiReader(strings.NewReader(`{"OgdSearchResult":`), iotest.ErrReader(readErr)))
+	if !errors.Is(err, readErr) {
+		t.Errorf("expected read error to be wrapped, got %v", err)
+	}
+
+	if _, err := DecodeSearchResponse(strings.NewReader("")); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF for empty body, got %v", err)
+	}
+}
+
+func TestDecodeSearchResponse_APIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantApp     string
+		wantMessage string
+	}{
+		{
+			name:        "object",
+			body:        `{"OgdSearchResult":{"Error":{"Applikation":"Bundesnormen","Message":"Der Parameter DokumenteProSeite ist ungültig."}}}`,
+			wantApp:     "Bundesnormen",
+			wantMessage: "Der Parameter DokumenteProSeite ist ungültig.",
+		},
+		{
+			name:        "string",
+			body:        `{"OgdSearchResult":{"Error":"Unbekannte Applikation"}}`,
+			wantMessage: "Unbekannte Applikation",
+		},
+		{
+			name:        "list",
+			body:        `{"OgdSearchResult":{"Error":[{"Applikation":"Vfgh","Message":"a"},{"Message":"b"}]}}`,
+			wantApp:     "Vfgh",
+			wantMessage: "a; b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeSearchResponse(strings.NewReader(tt.body))
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %v", err)
+			}
+			if apiErr.Applikation != tt.wantApp || apiErr.Message != tt.wantMessage {
+				t.Errorf("got %+v, want Applikation=%q Message=%q", apiErr, tt.wantApp, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestJudikatur_ParsesFullMetadata(t *testing.T) {
+	data := []byte(`{
+		"OgdSearchResult": {
+			"OgdDocumentResults": {
+				"Hits": "1",
+				"OgdDocumentReference": {
+					"Data": {
+						"Metadaten": {
+							"Technisch": {"ID": "JJR_20240115_OGH0002_0010OB00001_24A0000_001", "Applikation": "Justiz"},
+							"Allgemein": {"DokumentUrl": "https://example.com/jud"},
+							"Judikatur": {
+								"Dokumenttyp": "Text",
+								"Geschaeftszahl": {"item": ["1Ob1/24a", "1Ob2/24b"]},
+								"Normen": {"item": ["ABGB §1096", "MRG §3"]},
+								"Schlagworte": "Mietzinsminderung",
+								"EuropeanCaseLawIdentifier": "ECLI:AT:OGH0002:2024:0010OB00001.24A.0115.000",
+								"Justiz": {
+									"Entscheidungsdatum": "2024-01-15",
+									"Gericht": "OGH",
+									"Entscheidungsart": "Ordentliche Erledigung (Sachentscheidung)",
+									"Rechtssatznummern": {"item": "RS0021187"}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`)
+
+	result, err := ParseSearchResponse(data)
+	if err != nil {
+		t.Fatalf("ParseSearchResponse returned error: %v", err)
+	}
+	doc := result.Documents[0]
+
+	if doc.Geschaeftszahl != "1Ob1/24a" || !slices.Equal(doc.Geschaeftszahlen, []string{"1Ob1/24a", "1Ob2/24b"}) {
+		t.Errorf("Geschaeftszahl = %q, Geschaeftszahlen = %q", doc.Geschaeftszahl, doc.Geschaeftszahlen)
+	}
+	if doc.Dokumenttyp != "Entscheidungstext" {
+		t.Errorf("Dokumenttyp = %q", doc.Dokumenttyp)
+	}
+	if doc.Entscheidungsart != "Ordentliche Erledigung (Sachentscheidung)" {
+		t.Errorf("Entscheidungsart = %q", doc.Entscheidungsart)
+	}
+	if !slices.Equal(doc.Normen, []string{"ABGB §1096", "MRG §3"}) {
+		t.Errorf("Normen = %q", doc.Normen)
+	}
+	if !slices.Equal(doc.Schlagworte, []string{"Mietzinsminderung"}) {
+		t.Errorf("Schlagworte = %q", doc.Schlagworte)
+	}
+	if !slices.Equal(doc.Rechtssatznummern, []string{"RS0021187"}) {
+		t.Errorf("Rechtssatznummern = %q", doc.Rechtssatznummern)
+	}
+	if doc.Citation.Gericht != "OGH" || doc.Citation.Ecli != "ECLI:AT:OGH0002:2024:0010OB00001.24A.0115.000" {
+		t.Errorf("Citation = %+v", doc.Citation)
+	}
+	if doc.Citation.Entscheidungsdatum != "2024-01-15" {
+		t.Errorf("Entscheidungsdatum = %q", doc.Citation.Entscheidungsdatum)
+	}
+}
+
+func TestJudikatur_NormInSubApp(t *testing.T) {
+	data := []byte(`{"OgdSearchResult": {"OgdDocumentResults": {"Hits": "1", "OgdDocumentReference": {"Data": {"Metadaten": {
+		"Technisch": {"ID": "JFR_20231205_23G00123_01", "Applikation": "Vfgh"},
+		"Judikatur": {"Dokumenttyp": "Rechtssatz", "Vfgh": {"Entscheidungsdatum": "2023-12-05", "Norm": {"#text": "B-VG Art7"}}}
+	}}}}}}`)
+
+	result, err := ParseSearchResponse(data)
+	if err != nil {
+		t.Fatalf("ParseSearchResponse returned error: %v", err)
+	}
+	doc := result.Documents[0]
+	if !slices.Equal(doc.Normen, []string{"B-VG Art7"}) {
+		t.Errorf("Normen = %q", doc.Normen)
+	}
+	if doc.Citation.Gericht != "VfGH" || doc.Dokumenttyp != "Rechtssatz" {
+		t.Errorf("Gericht = %q, Dokumenttyp = %q", doc.Citation.Gericht, doc.Dokumenttyp)
+	}
+}
+
+func TestJudikatur_SkipsMalformedFields(t *testing.T) {
+	// Normen and Rechtssatznummern cannot be decoded; the rest of the
+	// section, including the court's sub-section, must survive.
+	data := []byte(`{"OgdSearchResult": {"OgdDocumentResults": {"Hits": "1", "OgdDocumentReference": {"Data": {"Metadaten": {
+		"Technisch": {"ID": "JJR_20240115_OGH0002_0010OB00001_24A0000_001", "Applikation": "Justiz"},
+		"Judikatur": {"Dokumenttyp": "Rechtssatz", "Geschaeftszahl": "1Ob1/24a", "Normen": 42, "Kurztitel": {"a": 1},
+			"Justiz": {"Entscheidungsdatum": "2024-01-15", "Gericht": "OGH", "Rechtssatznummern": true}}
+	}}}}}}`)
+
+	result, err := ParseSearchResponse(data)
+	if err != nil {
+		t.Fatalf("ParseSearchResponse returned error: %v", err)
+	}
+	doc := result.Documents[0]
+	if doc.Geschaeftszahl != "1Ob1/24a" || doc.Dokumenttyp != "Rechtssatz" {
+		t.Errorf("Geschaeftszahl = %q, Dokumenttyp = %q", doc.Geschaeftszahl, doc.Dokumenttyp)
+	}
+	if doc.Citation.Gericht != "OGH" || doc.Citation.Entscheidungsdatum != "2024-01-15" {
+		t.Errorf("Citation = %+v", doc.Citation)
+	}
+	if len(doc.Normen) != 0 || len(doc.Rechtssatznummern) != 0 || doc.Kurztitel != "" {
+		t.Errorf("malformed fields decoded: Normen = %q, Rechtssatznummern = %q, Kurztitel = %q", doc.Normen, doc.Rechtssatznummern, doc.Kurztitel)
+	}
+}
+
+func TestSonstige_ParsesMetadata(t *testing.T) {
+	data := []byte(`{"OgdSearchResult": {"OgdDocumentResults": {"Hits": "3", "OgdDocumentReference": [
+		{"Data": {"Metadaten": {
+			"Technisch": {"ID": "MRP_20240110_01", "Applikation": "Mrp"},
+			"Sonstige": {"Kurztitel": "Ministerratsprotokoll Nr. 1", "Titel": "Ministerrat vom 10. Jänner 2024",
+				"Mrp": {"Sitzungsdatum": "2024-01-10", "Sitzungsnummer": "1", "Gesetzgebungsperiode": "27", "Einbringer": "BKA", "Geschaeftszahl": "2024-0.001.234"}}
+		}}},
+		{"Data": {"Metadaten": {
+			"Technisch": {"ID": "ERL_BMF_20230601_001", "Applikation": "Erlaesse"},
+			"Sonstige": {"Kurztitel": "Umsatzsteuerrichtlinien 2000", "Titel": {"#text": "UStR 2000 Wartungserlass 2023"},
+				"Erlaesse": {"Bundesministerium": "BMF", "Abteilung": "IV/9", "Geschaeftszahl": "2023-0.123.456", "Veroeffentlicht": "2023-06-01",
+					"Inkrafttretensdatum": "2023-06-01", "Ausserkrafttretensdatum": "9999-12-31", "Norm": {"item": ["UStG 1994 §6", "UStG 1994 §12"]}}}
+		}}},
+		{"Data": {"Metadaten": {
+			"Technisch": {"ID": "AVSV_2024_0012", "Applikation": "Avsv"},
+			"Sonstige": {"Titel": "Änderung der Satzung",
+				"Avsv": {"Avsvnummer": "12/2024", "Urheber": "ÖGK", "Dokumentart": "Satzung", "Kundmachungsdatum": "2024-02-01"}}
+		}}}
+	]}}}`)
+
+	result, err := ParseSearchResponse(data)
+	if err != nil {
+		t.Fatalf("ParseSearchResponse returned error: %v", err)
+	}
+	if len(result.Documents) != 3 {
+		t.Fatalf("got %d documents, want 3", len(result.Documents))
+	}
+
+	mrp := result.Documents[0]
+	if mrp.Titel != "Ministerrat vom 10. Jänner 2024" || mrp.Kurztitel != "Ministerratsprotokoll Nr. 1" {
+		t.Errorf("Mrp title = %q, Kurztitel = %q", mrp.Titel, mrp.Kurztitel)
+	}
+	if mrp.Sitzungsnummer != "1" || mrp.Sitzungsdatum != "2024-01-10" || mrp.Gesetzgebungsperiode != "27" || mrp.Einbringer != "BKA" {
+		t.Errorf("Mrp session = %+v", mrp)
+	}
+	if mrp.Geschaeftszahl != "2024-0.001.234" || mrp.Citation.Geschaeftszahl != "2024-0.001.234" {
+		t.Errorf("Mrp Geschaeftszahl = %q", mrp.Geschaeftszahl)
+	}
+	if !slices.Equal(mrp.Geschaeftszahlen, []string{"2024-0.001.234"}) {
+		t.Errorf("Mrp Geschaeftszahlen = %q", mrp.Geschaeftszahlen)
+	}
+
+	erl := result.Documents[1]
+	if erl.Titel != "UStR 2000 Wartungserlass 2023" || erl.Bundesministerium != "BMF" || erl.Abteilung != "IV/9" {
+		t.Errorf("Erlaesse = %+v", erl)
+	}
+	if !slices.Equal(erl.Normen, []string{"UStG 1994 §6", "UStG 1994 §12"}) {
+		t.Errorf("Erlaesse Normen = %q", erl.Normen)
+	}
+	if erl.Citation.Inkrafttreten != "2023-06-01" || erl.Citation.Ausserkrafttreten != nil || erl.Citation.Kundmachungsdatum != "2023-06-01" {
+		t.Errorf("Erlaesse Citation = %+v", erl.Citation)
+	}
+
+	avsv := result.Documents[2]
+	if avsv.Nummer != "12/2024" || avsv.Urheber != "ÖGK" || avsv.Typ != "Satzung" || avsv.Dokumenttyp != "" || avsv.Citation.Kundmachungsdatum != "2024-02-01" {
+		t.Errorf("Avsv = %+v, Citation = %+v", avsv, avsv.Citation)
+	}
+}
+
+func TestSonstige_SkipsMalformedFields(t *testing.T) {
+	// Titel and Abteilung cannot be decoded; the rest of the section,
+	// including the application's sub-section, must survive.
+	data := []byte(`{"OgdSearchResult": {"OgdDocumentResults": {"Hits": "1", "OgdDocumentReference": {"Data": {"Metadaten": {
+		"Technisch": {"ID": "ERL_BMF_20230601_001", "Applikation": "Erlaesse"},
+		"Sonstige": {"Kurztitel": "Umsatzsteuerrichtlinien 2000", "Titel": [{"a": 1}],
+			"Erlaesse": {"Bundesministerium": "BMF", "Abteilung": {"a": [1]}, "Geschaeftszahl": "2023-0.123.456", "Inkrafttretensdatum": "2023-06-01"}}
+	}}}}}}`)
+
+	result, err := ParseSearchResponse(data)
+	if err != nil {
+		t.Fatalf("ParseSearchResponse returned error: %v", err)
+	}
+	doc := result.Documents[0]
+	if doc.Kurztitel != "Umsatzsteuerrichtlinien 2000" || doc.Bundesministerium != "BMF" || doc.Geschaeftszahl != "2023-0.123.456" {
+		t.Errorf("Kurztitel = %q, Bundesministerium = %q, Geschaeftszahl = %q", doc.Kurztitel, doc.Bundesministerium, doc.Geschaeftszahl)
+	}
+	if doc.Citation.Inkrafttreten != "2023-06-01" || doc.Titel != "" || doc.Abteilung != "" {
+		t.Errorf("Inkrafttreten = %q, Titel = %q, Abteilung = %q", doc.Citation.Inkrafttreten, doc.Titel, doc.Abteilung)
+	}
+}
+
+func TestBezirkeGemeindenVbl_ParsesMetadata(t *testing.T) {
+	data := []byte(`{"OgdSearchResult": {"OgdDocumentResults": {"Hits": "3", "OgdDocumentReference": [
+		{"Data": {"Metadaten": {
+			"Technisch": {"ID": "BVB_NO_20240101_123", "Applikation": "Bvb"},
+			"Bezirke": {"Kurztitel": "Verordnung Halteverbot Hauptstraße", "Titel": {"#text": "Halteverbot"}, "Bundesland": "Niederösterreich",
+				"Bezirksverwaltungsbehoerde": "BH Mödling", "Kundmachungsnummer": "12/2024", "Kundmachungsdatum": "2024-01-01"}
+		}}},
+		{"Data": {"Metadaten": {
+			"Technisch": {"ID": "GRA_ST_60101_2024_001", "Applikation": "Gr"},
+			"Gemeinden": {"Kurztitel": "Kanalabgabenordnung", "Titel": "Kanalabgabenordnung der Stadt Graz", "Bundesland": "Steiermark",
+				"Gemeinde": "Graz", "Geschaeftszahl": {"item": "A8-123/2024"}, "Inkrafttretensdatum": "2024-03-01"}
+		}}},
+		{"Data": {"Metadaten": {
+			"Technisch": {"ID": "VBL_K_20240201_5", "Applikation": "Vbl"},
+			"Landesrecht": {"Kurztitel": "Jagdzeitenverordnung", "Titel": "Jagdzeitenverordnung",
+				"Vbl": {"Bundesland": "Kärnten", "Kundmachungsnummer": "5/2024", "Kundmachungsdatum": "2024-02-01", "Inkrafttretensdatum": "2024-02-02"}}
+		}}}
+	]}}}`)
+
+	result, err := ParseSearchResponse(data)
+	if err != nil {
+		t.Fatalf("ParseSearchResponse returned error: %v", err)
+	}
+	if len(result.Documents) != 3 {
+		t.Fatalf("got %d documents, want 3", len(result.Documents))
+	}
+
+	bvb := result.Documents[0]
+	if bvb.Titel != "Halteverbot" || bvb.Behoerde != "BH Mödling" || bvb.Bundesland != "Niederösterreich" {
+		t.Errorf("Bezirke = %+v", bvb)
+	}
+	if bvb.Citation.Kundmachungsnummer != "12/2024" || bvb.Citation.Kundmachungsdatum != "2024-01-01" {
+		t.Errorf("Bezirke Citation = %+v", bvb.Citation)
+	}
+
+	gr := result.Documents[1]
+	if gr.Gemeinde != "Graz" || gr.Bundesland != "Steiermark" || gr.Geschaeftszahl != "A8-123/2024" {
+		t.Errorf("Gemeinden = %+v", gr)
+	}
+	if !slices.Equal(gr.Geschaeftszahlen, []string{"A8-123/2024"}) {
+		t.Errorf("Gemeinden Geschaeftszahlen = %q", gr.Geschaeftszahlen)
+	}
+	if gr.Citation.Inkrafttreten != "2024-03-01" || gr.Citation.Ausserkrafttreten != nil {
+		t.Errorf("Gemeinden Citation = %+v", gr.Citation)
+	}
+
+	vbl := result.Documents[2]
+	if vbl.Bundesland != "Kärnten" || vbl.Citation.Kundmachungsnummer != "5/2024" || vbl.Citation.Kundmachungsdatum != "2024-02-01" {
+		t.Errorf("Vbl = %+v, Citation = %+v", vbl, vbl.Citation)
+	}
+	if vbl.Citation.Inkrafttreten != "2024-02-02" {
+		t.Errorf("Vbl Inkrafttreten = %q", vbl.Citation.Inkrafttreten)
+	}
+}
+
+func TestBezirkeGemeinden_SkipsMalformedFields(t *testing.T) {
+	data := []byte(`{"OgdSearchResult": {"OgdDocumentResults": {"Hits": "2", "OgdDocumentReference": [
+		{"Data": {"Metadaten": {
+			"Technisch": {"ID": "BVB_NO_20240101_123", "Applikation": "Bvb"},
+			"Bezirke": {"Kurztitel": "Halteverbot Hauptstraße", "Bezirksverwaltungsbehoerde": [{"a": 1}], "Kundmachungsnummer": "12/2024"}
+		}}},
+		{"Data": {"Metadaten": {
+			"Technisch": {"ID": "GRA_ST_60101_2024_001", "Applikation": "Gr"},
+			"Gemeinden": {"Kurztitel": "Kanalabgabenordnung", "Gemeinde": "Graz", "Geschaeftszahl": {"item": {"a": 1}}}
+		}}}
+	]}}}`)
+
+	result, err := ParseSearchResponse(data)
+	if err != nil {
+		t.Fatalf("ParseSearchResponse returned error: %v", err)
+	}
+	bvb, gr := result.Documents[0], result.Documents[1]
+	if bvb.Kurztitel != "Halteverbot Hauptstraße" || bvb.Behoerde != "" || bvb.Citation.Kundmachungsnummer != "12/2024" {
+		t.Errorf("Bezirke = %+v, Citation = %+v", bvb, bvb.Citation)
+	}
+	if gr.Kurztitel != "Kanalabgabenordnung" || gr.Gemeinde != "Graz" || len(gr.Geschaeftszahlen) != 0 {
+		t.Errorf("Gemeinden = %+v", gr)
+	}
+}
+
+func TestBundesrecht_ParsesLawMetadata(t *testing.T) {
+	data := []byte(`{"OgdSearchResult": {"OgdDocumentResults": {"Hits": "1", "OgdDocumentReference": {"Data": {"Metadaten": {
+		"Technisch": {"ID": "NOR40052761", "Applikation": "BrKons"},
+		"Bundesrecht": {"Kurztitel": "ABGB", "Titel": "§ 1295",
+			"BrKons": {
+				"Kundmachungsorgan": "JGS Nr. 946/1811",
+				"ArtikelParagraphAnlage": "§ 1295",
+				"Gesetzesnummer": "10001622",
+				"Typ": "BG",
+				"Indizes": {"item": ["20/01 Allgemeines bürgerliches Recht"]},
+				"Schlagworte": "Schadenersatz",
+				"Unterzeichnungsdatum": "1811-06-01",
+				"Aenderung": {"item": ["BGBl. I Nr. 98/2001", "BGBl. I Nr. 87/2015"]}
+			}}
+	}}}}}}`)
+
+	result, err := ParseSearchResponse(data)
+	if err != nil {
+		t.Fatalf("ParseSearchResponse returned error: %v", err)
+	}
+	doc := result.Documents[0]
+
+	if doc.Gesetzesnummer != "10001622" || doc.Normtyp != "BG" || doc.Dokumenttyp != "" {
+		t.Errorf("Gesetzesnummer = %q, Normtyp = %q, Dokumenttyp = %q", doc.Gesetzesnummer, doc.Normtyp, doc.Dokumenttyp)
+	}
+	if !slices.Equal(doc.Indizes, []string{"20/01 Allgemeines bürgerliches Recht"}) || !slices.Equal(doc.Schlagworte, []string{"Schadenersatz"}) {
+		t.Errorf("Indizes = %q, Schlagworte = %q", doc.Indizes, doc.Schlagworte)
+	}
+	if !slices.Equal(doc.Aenderungen, []string{"BGBl. I Nr. 98/2001", "BGBl. I Nr. 87/2015"}) {
+		t.Errorf("Aenderungen = %q", doc.Aenderungen)
+	}
+	if doc.Citation.Unterzeichnungsdatum != "1811-06-01" {
+		t.Errorf("Unterzeichnungsdatum = %q", doc.Citation.Unterzeichnungsdatum)
+	}
+	// Without a URL from the API, the link is built from the Gesetzesnummer.
+	if want := "https://ris.bka.gv.at/GeltendeFassung.wxe?Abfrage=Bundesnormen&Gesetzesnummer=10001622"; doc.GesamteRechtsvorschriftURL != want {
+		t.Errorf("GesamteRechtsvorschriftURL = %q, want %q", doc.GesamteRechtsvorschriftURL, want)
+	}
+}
+
+func TestBundesrecht_SkipsMalformedFields(t *testing.T) {
+	// Indizes and Kurztitel cannot be decoded; the rest of the section,
+	// including the BrKons sub-section, must survive.
+	data := []byte(`{"OgdSearchResult": {"OgdDocumentResults": {"Hits": "1", "OgdDocumentReference": {"Data": {"Metadaten": {
+		"Technisch": {"ID": "NOR40052761", "Applikation": "BrKons"},
+		"Bundesrecht": {"Kurztitel": "ABGB", "Langtitel": {"a": 1}, "Eli": "eli/jgs/1811/946/P1295/NOR40052761",
+			"BrKons": {"Kundmachungsorgan": "JGS Nr. 946/1811", "Gesetzesnummer": "10001622", "Indizes": {"item": {"a": 1}}}}
+	}}}}}}`)
+
+	result, err := ParseSearchResponse(data)
+	if err != nil {
+		t.Fatalf("ParseSearchResponse returned error: %v", err)
+	}
+	doc := result.Documents[0]
+	if doc.Kurztitel != "ABGB" || doc.Citation == nil {
+		t.Fatalf("Kurztitel = %q, Citation = %+v", doc.Kurztitel, doc.Citation)
+	}
+	if doc.Citation.Eli != "eli/jgs/1811/946/P1295/NOR40052761" || doc.Citation.Kundmachungsorgan != "JGS Nr. 946/1811" {
+		t.Errorf("Citation = %+v", doc.Citation)
+	}
+	if doc.Gesetzesnummer != "10001622" || len(doc.Indizes) != 0 || doc.Citation.Langtitel != "" {
+		t.Errorf("Gesetzesnummer = %q, Indizes = %q, Langtitel = %q", doc.Gesetzesnummer, doc.Indizes, doc.Citation.Langtitel)
+	}
+}
+
+func TestLandesrecht_SkipsMalformedFields(t *testing.T) {
+	data := []byte(`{"OgdSearchResult": {"OgdDocumentResults": {"Hits": "1", "OgdDocumentReference": {"Data": {"Metadaten": {
+		"Technisch": {"ID": "VBL_K_20240201_5", "Applikation": "Vbl"},
+		"Landesrecht": {"Kurztitel": "Jagdzeitenverordnung",
+			"Vbl": {"Bundesland": "Kärnten", "Kundmachungsnummer": "5/2024", "Schlagworte": 7, "Inkrafttretensdatum": "2024-02-02"}}
+	}}}}}}`)
+
+	result, err := ParseSearchResponse(data)
+	if err != nil {
+		t.Fatalf("ParseSearchResponse returned error: %v", err)
+	}
+	doc := result.Documents[0]
+	if doc.Kurztitel != "Jagdzeitenverordnung" || doc.Bundesland != "Kärnten" || len(doc.Schlagworte) != 0 {
+		t.Errorf("Kurztitel = %q, Bundesland = %q, Schlagworte = %q", doc.Kurztitel, doc.Bundesland, doc.Schlagworte)
+	}
+	if doc.Citation.Kundmachungsnummer != "5/2024" || doc.Citation.Inkrafttreten != "2024-02-02" {
+		t.Errorf("Citation = %+v", doc.Citation)
+	}
+}
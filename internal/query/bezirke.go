@@ -0,0 +1,48 @@
+package query
+
+import (
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/constants"
+)
+
+// BezirkeQuery searches announcements of the district administrative
+// authorities (Bezirksverwaltungsbehörden).
+type BezirkeQuery struct {
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// State restricts the search to one state, e.g. tirol.
+	State string
+	// Authority is the district authority, e.g.
+	// "Bezirkshauptmannschaft Innsbruck".
+	Authority string
+	// Number is the announcement number.
+	Number string
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// Since restricts to documents added to RIS within a period, e.g.
+	// einemmonat.
+	Since string
+}
+
+// Endpoint implements Query.
+func (q BezirkeQuery) Endpoint() string { return api.EndpointBezirke }
+
+// Params implements Query. At least one of Search, Title, State, Authority
+// or Number is required.
+func (q BezirkeQuery) Params() (*api.Params, error) {
+	e := newEncoder()
+	e.require([]string{"Search", "Title", "State", "Authority", "Number"}, q.Search, q.Title, q.State, q.Authority, q.Number)
+	e.set("Applikation", "Bvb")
+	e.set("Suchworte", q.Search)
+	e.set("Titel", q.Title)
+	// Bezirke uses display names with Umlauts.
+	e.lookup("State", "Bundesland", q.State, constants.BezirkeStates, statesValid)
+	e.set("Bezirksverwaltungsbehoerde", q.Authority)
+	e.set("Kundmachungsnummer", q.Number)
+	e.date("From", "Kundmachungsdatum.Von", q.From)
+	e.date("To", "Kundmachungsdatum.Bis", q.To)
+	e.lookup("Since", "ImRisSeit", q.Since, constants.ImRisSeit, sinceValid)
+	return e.result()
+}
@@ -0,0 +1,134 @@
+package query
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/constants"
+)
+
+// BundesrechtQuery searches federal law (Bundesrecht).
+type BundesrechtQuery struct {
+	// App is the application: brkons (default), begut, bgblauth or erv.
+	App string
+	// Search is a full-text search.
+	Search string
+	// Title searches the title of the law.
+	Title string
+	// Paragraph restricts the search to a paragraph number, e.g. "1295".
+	Paragraph string
+	// Date selects the version in force on that date (JJJJ-MM-TT).
+	Date string
+	// LawNumber is the Gesetzesnummer, e.g. "10001622" for the ABGB.
+	LawNumber string
+	// Type is the norm type, e.g. BG, BVG or V.
+	Type string
+	// Index searches the subject index (Sachgebiet), e.g. "20/01".
+	Index string
+	// Keywords searches the keywords (Schlagworte).
+	Keywords string
+}
+
+// Endpoint implements Query.
+func (q BundesrechtQuery) Endpoint() string { return api.EndpointBundesrecht }
+
+// Params implements Query. At least one of Search, Title, Paragraph,
+// LawNumber, Index or Keywords is required. Type and Index are only
+// supported by the consolidated law (brkons).
+func (q BundesrechtQuery) Params() (*api.Params, error) {
+	e := newEncoder()
+	e.require([]string{"Search", "Title", "Paragraph", "LawNumber", "Index", "Keywords"},
+		q.Search, q.Title, q.Paragraph, q.LawNumber, q.Index, q.Keywords)
+	app := cmp.Or(q.App, "brkons")
+	e.lookup("App", "Applikation", app, constants.BundesrechtApps, []string{"brkons", "begut", "bgblauth", "erv"})
+	if !strings.EqualFold(app, "brkons") {
+		e.onlyWith([]string{"Type", "Index"}, []string{q.Type, q.Index}, "App", "brkons")
+	}
+	e.set("Suchworte", q.Search)
+	e.set("Titel", q.Title)
+	if q.Paragraph != "" {
+		e.set("Abschnitt.Von", q.Paragraph)
+		e.set("Abschnitt.Bis", q.Paragraph)
+		e.set("Abschnitt.Typ", "Paragraph")
+	}
+	e.date("Date", "FassungVom", q.Date)
+	e.set("Gesetzesnummer", q.LawNumber)
+	e.set("Typ", q.Type)
+	e.set("Index", q.Index)
+	e.set("Schlagworte", q.Keywords)
+	return e.result()
+}
+
+// BgblQuery searches the federal law gazette (Bundesgesetzblatt).
+type BgblQuery struct {
+	// App is the application: bgblauth (default), bgblpdf or bgblalt.
+	App string
+	// Number is the gazette number.
+	Number string
+	// Year is the volume (Jahrgang).
+	Year string
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// Part is the gazette part: 1 (laws), 2 (ordinances) or 3 (treaties).
+	Part string
+}
+
+// Endpoint implements Query.
+func (q BgblQuery) Endpoint() string { return api.EndpointBundesrecht }
+
+// Params implements Query. At least one of Number, Year, Search or Title is
+// required.
+func (q BgblQuery) Params() (*api.Params, error) {
+	e := newEncoder()
+	e.require([]string{"Number", "Year", "Search", "Title"}, q.Number, q.Year, q.Search, q.Title)
+	e.lookup("App", "Applikation", cmp.Or(q.App, "bgblauth"), constants.BgblApps, []string{"bgblauth", "bgblpdf", "bgblalt"})
+	e.set("Bgblnummer", q.Number)
+	e.set("Jahrgang", q.Year)
+	e.set("Suchworte", q.Search)
+	e.set("Titel", q.Title)
+	e.lookup("Part", "Teil", q.Part, constants.BgblTeile, []string{"1", "2", "3"})
+	return e.result()
+}
+
+// RegvorlQuery searches government bills (Regierungsvorlagen).
+type RegvorlQuery struct {
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// From and To bound the resolution date (JJJJ-MM-TT).
+	From, To string
+	// Ministry is the submitting ministry, e.g. bmf or bmj.
+	Ministry string
+	// Since restricts to documents added to RIS within a period, e.g.
+	// einemmonat.
+	Since string
+	// SortDir is the sort direction: asc or desc.
+	SortDir string
+	// SortBy is the sort column: kurztitel, stelle or datum.
+	SortBy string
+}
+
+// Endpoint implements Query.
+func (q RegvorlQuery) Endpoint() string { return api.EndpointBundesrecht }
+
+// Params implements Query. At least one of Search, Title, From, Ministry or
+// Since is required.
+func (q RegvorlQuery) Params() (*api.Params, error) {
+	e := newEncoder()
+	e.require([]string{"Search", "Title", "From", "Ministry", "Since"}, q.Search, q.Title, q.From, q.Ministry, q.Since)
+	e.set("Applikation", "RegV")
+	e.set("Suchworte", q.Search)
+	e.set("Titel", q.Title)
+	e.date("From", "BeschlussdatumVon", q.From)
+	e.date("To", "BeschlussdatumBis", q.To)
+	e.lookup("Ministry", "EinbringendeStelle", q.Ministry, constants.RegvorlMinistries,
+		[]string{"bka", "bmkoes", "bmeia", "bmaw", "bmbwf", "bmf", "bmi", "bmj", "bmk", "bmlv", "bml", "bmsgpk", "bmffim", "bmeuv"})
+	e.lookup("Since", "ImRisSeit", q.Since, constants.ImRisSeit, sinceValid)
+	e.lookup("SortDir", "Sortierung.SortDirection", q.SortDir, constants.SortDirections, sortDirValid)
+	e.lookup("SortBy", "Sortierung.SortedByColumn", q.SortBy, constants.RegvorlSortColumns, []string{"kurztitel", "stelle", "datum"})
+	return e.result()
+}
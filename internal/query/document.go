@@ -0,0 +1,32 @@
+package query
+
+import (
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/constants"
+	"github.com/philrox/risgo/internal/model"
+)
+
+// DocumentQuery looks up a single document by its document number, e.g. to
+// find its content URLs when they cannot be derived from the number.
+type DocumentQuery struct {
+	// Number is the document number, e.g. "NOR40000001". Required.
+	Number string
+}
+
+// Endpoint implements Query. The endpoint is derived from the number's
+// prefix.
+func (q DocumentQuery) Endpoint() string {
+	endpoint, _ := model.SearchFallback(q.Number)
+	return endpoint
+}
+
+// Params implements Query.
+func (q DocumentQuery) Params() (*api.Params, error) {
+	_, applikation := model.SearchFallback(q.Number)
+	e := newEncoder()
+	e.require([]string{"Number"}, q.Number)
+	e.set("Applikation", applikation)
+	e.set("Dokumentnummer", q.Number)
+	e.set("DokumenteProSeite", constants.PageSizes[10])
+	return e.result()
+}
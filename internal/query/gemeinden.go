@@ -0,0 +1,73 @@
+package query
+
+import (
+	"cmp"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/constants"
+)
+
+// GemeindenQuery searches municipal law. Some fields apply to only one of
+// the two applications: Gr (consolidated municipal law) and GrA
+// (municipal gazettes).
+type GemeindenQuery struct {
+	// App is the application: gr (default) or gra.
+	App string
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// State is the state name as used by the API, e.g. "Tirol".
+	State string
+	// Municipality is the municipality name, e.g. "Graz".
+	Municipality string
+	// FileNumber is the file number (Gr only).
+	FileNumber string
+	// Index is the subject index (Gr only), e.g. gesundheit.
+	Index string
+	// District is the district (GrA only).
+	District string
+	// Gemeindeverband is the association of municipalities (GrA only).
+	Gemeindeverband string
+	// AnnouncementNr is the announcement number (GrA only).
+	AnnouncementNr string
+	// Date selects the version in force on that date (Gr only, JJJJ-MM-TT).
+	Date string
+	// From and To bound the announcement date (GrA only, JJJJ-MM-TT).
+	From, To string
+	// Since restricts to documents added to RIS within a period, e.g.
+	// einemmonat.
+	Since string
+	// SortDir is the sort direction: asc or desc.
+	SortDir string
+	// SortBy is the sort column (Gr only): geschaeftszahl, bundesland or
+	// gemeinde.
+	SortBy string
+}
+
+// Endpoint implements Query.
+func (q GemeindenQuery) Endpoint() string { return api.EndpointGemeinden }
+
+// Params implements Query. At least one search field is required.
+func (q GemeindenQuery) Params() (*api.Params, error) {
+	e := newEncoder()
+	e.require([]string{"Search", "Title", "State", "Municipality", "FileNumber", "Index", "District", "Gemeindeverband", "AnnouncementNr"},
+		q.Search, q.Title, q.State, q.Municipality, q.FileNumber, q.Index, q.District, q.Gemeindeverband, q.AnnouncementNr)
+	e.lookup("App", "Applikation", cmp.Or(q.App, "gr"), constants.GemeindenApps, []string{"gr", "gra"})
+	e.set("Suchworte", q.Search)
+	e.set("Titel", q.Title)
+	e.set("Bundesland", q.State)
+	e.set("Gemeinde", q.Municipality)
+	e.set("Geschaeftszahl", q.FileNumber)
+	e.lookup("Index", "Index", q.Index, constants.GemeindenIndex, nil)
+	e.set("Bezirk", q.District)
+	e.set("Gemeindeverband", q.Gemeindeverband)
+	e.set("Kundmachungsnummer", q.AnnouncementNr)
+	e.date("Date", "FassungVom", q.Date)
+	e.date("From", "Kundmachungsdatum.Von", q.From)
+	e.date("To", "Kundmachungsdatum.Bis", q.To)
+	e.lookup("Since", "ImRisSeit", q.Since, constants.ImRisSeit, sinceValid)
+	e.lookup("SortDir", "Sortierung.SortDirection", q.SortDir, constants.SortDirections, sortDirValid)
+	e.lookup("SortBy", "Sortierung.SortedByColumn", q.SortBy, constants.GemeindenSortColumns, []string{"geschaeftszahl", "bundesland", "gemeinde"})
+	return e.result()
+}
@@ -0,0 +1,82 @@
+package query
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/constants"
+)
+
+// JudikaturQuery searches court decisions (Judikatur).
+type JudikaturQuery struct {
+	// Court is the court or collection: justiz (default), vfgh, vwgh, bvwg,
+	// lvwg, dsk, asylgh, normenliste, pvak, gbk or dok.
+	Court string
+	// Search is a full-text search.
+	Search string
+	// Norm searches cited norms, e.g. "1319a ABGB".
+	Norm string
+	// CaseNumber is the case number (Geschäftszahl), e.g. "5Ob234/20b".
+	CaseNumber string
+	// From and To bound the decision date (JJJJ-MM-TT).
+	From, To string
+}
+
+// Endpoint implements Query.
+func (q JudikaturQuery) Endpoint() string { return api.EndpointJudikatur }
+
+// Params implements Query. At least one of Search, Norm or CaseNumber is
+// required.
+func (q JudikaturQuery) Params() (*api.Params, error) {
+	e := newEncoder()
+	e.require([]string{"Search", "Norm", "CaseNumber"}, q.Search, q.Norm, q.CaseNumber)
+	e.lookup("Court", "Applikation", cmp.Or(q.Court, "justiz"), constants.Courts,
+		[]string{"justiz", "vfgh", "vwgh", "bvwg", "lvwg", "dsk", "asylgh", "normenliste", "pvak", "gbk", "dok"})
+	e.set("Suchworte", q.Search)
+	e.set("Norm", q.Norm)
+	e.set("Geschaeftszahl", q.CaseNumber)
+	e.date("From", "EntscheidungsdatumVon", q.From)
+	e.date("To", "EntscheidungsdatumBis", q.To)
+	return e.result()
+}
+
+// historyAppsValid lists the History applications in the order they are
+// shown in error messages.
+var historyAppsValid = []string{
+	"bundesnormen", "landesnormen", "justiz", "vfgh", "vwgh", "bvwg", "lvwg", "bgblauth", "bgblalt", "bgblpdf",
+	"lgblauth", "lgbl", "lgblno", "gemeinderecht", "gemeinderechtauth", "bvb", "vbl", "regv", "mrp", "erlaesse",
+	"pruefgewo", "avsv", "spg", "kmger", "dsk", "gbk", "dok", "pvak", "normenliste", "asylgh",
+}
+
+// HistoryQuery searches the change history of an application.
+type HistoryQuery struct {
+	// App is the application, e.g. bundesnormen or justiz. Required.
+	App string
+	// From and To bound the change date (JJJJ-MM-TT).
+	From, To string
+	// IncludeDeleted includes deleted documents.
+	IncludeDeleted bool
+}
+
+// Endpoint implements Query.
+func (q HistoryQuery) Endpoint() string { return api.EndpointHistory }
+
+// Params implements Query. App and at least one of From or To are required.
+func (q HistoryQuery) Params() (*api.Params, error) {
+	e := newEncoder()
+	e.require([]string{"App"}, q.App)
+	e.require([]string{"From", "To"}, q.From, q.To)
+	app := strings.ToLower(q.App)
+	if e.err == nil && !constants.IsValidHistoryApp(app) {
+		e.err = &Error{Fields: []string{"App"}, Value: q.App, Valid: historyAppsValid}
+	}
+	// History uses Anwendung, NOT Applikation.
+	e.set("Anwendung", app)
+	e.date("From", "AenderungenVon", q.From)
+	e.date("To", "AenderungenBis", q.To)
+	if q.IncludeDeleted {
+		e.set("IncludeDeletedDocuments", "true")
+	}
+	return e.result()
+}
@@ -0,0 +1,114 @@
+package query
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/constants"
+)
+
+// LandesrechtQuery searches consolidated state law (Landesrecht).
+type LandesrechtQuery struct {
+	// Search is a full-text search.
+	Search string
+	// Title searches the title of the law.
+	Title string
+	// State restricts the search to one state, e.g. wien or tirol.
+	State string
+}
+
+// Endpoint implements Query.
+func (q LandesrechtQuery) Endpoint() string { return api.EndpointLandesrecht }
+
+// Params implements Query. At least one of Search, Title or State is
+// required.
+func (q LandesrechtQuery) Params() (*api.Params, error) {
+	e := newEncoder()
+	e.require([]string{"Search", "Title", "State"}, q.Search, q.Title, q.State)
+	e.set("Applikation", "LrKons")
+	e.set("Suchworte", q.Search)
+	e.set("Titel", q.Title)
+	e.stateFlag(q.State)
+	return e.result()
+}
+
+// LgblQuery searches the state law gazettes (Landesgesetzblätter).
+type LgblQuery struct {
+	// App is the application: lgblauth (default), lgbl or lgblno.
+	App string
+	// Number is the gazette number.
+	Number string
+	// Year is the volume (Jahrgang).
+	Year string
+	// State restricts the search to one state, e.g. wien or tirol.
+	State string
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+}
+
+// Endpoint implements Query.
+func (q LgblQuery) Endpoint() string { return api.EndpointLandesrecht }
+
+// Params implements Query. At least one of Number, Year, State, Search or
+// Title is required.
+func (q LgblQuery) Params() (*api.Params, error) {
+	e := newEncoder()
+	e.require([]string{"Number", "Year", "State", "Search", "Title"}, q.Number, q.Year, q.State, q.Search, q.Title)
+	e.lookup("App", "Applikation", cmp.Or(q.App, "lgblauth"), constants.LgblApps, []string{"lgblauth", "lgbl", "lgblno"})
+	e.set("Lgblnummer", q.Number)
+	e.set("Jahrgang", q.Year)
+	e.stateFlag(q.State)
+	e.set("Suchworte", q.Search)
+	e.set("Titel", q.Title)
+	return e.result()
+}
+
+// VerordnungenQuery searches the state ordinance gazettes (Verordnungsblätter).
+type VerordnungenQuery struct {
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// State restricts the search to one state, e.g. wien or tirol.
+	State string
+	// Number is the announcement number.
+	Number string
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+}
+
+// Endpoint implements Query.
+func (q VerordnungenQuery) Endpoint() string { return api.EndpointLandesrecht }
+
+// Params implements Query. At least one of Search, Title, State, Number or
+// From is required.
+func (q VerordnungenQuery) Params() (*api.Params, error) {
+	e := newEncoder()
+	e.require([]string{"Search", "Title", "State", "Number", "From"}, q.Search, q.Title, q.State, q.Number, q.From)
+	e.set("Applikation", "Vbl")
+	e.set("Suchworte", q.Search)
+	e.set("Titel", q.Title)
+	// Vbl takes the state name directly, not the SucheIn* switches.
+	e.lookup("State", "Bundesland", q.State, constants.VerordnungenStates, statesValid)
+	e.set("Kundmachungsnummer", q.Number)
+	e.date("From", "Kundmachungsdatum.Von", q.From)
+	e.date("To", "Kundmachungsdatum.Bis", q.To)
+	return e.result()
+}
+
+// stateFlag sets the Bundesland.SucheIn* switch for state to "true". An
+// empty state is skipped.
+func (e *encoder) stateFlag(state string) {
+	if e.err != nil || state == "" {
+		return
+	}
+	name, ok := constants.LandesrechtStates[strings.ToLower(state)]
+	if !ok {
+		e.err = &Error{Fields: []string{"State"}, Value: state, Valid: statesValid}
+		return
+	}
+	e.params.Set(name, "true")
+}
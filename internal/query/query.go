@@ -0,0 +1,227 @@
+// Package query provides typed search queries for the RIS collections.
+//
+// Each query type describes the search fields of one application in plain Go
+// terms, validates them and encodes them to the parameter vocabulary of the
+// RIS OGD API (Suchworte, Abschnitt.Von, Bundesland.SucheInWien, …). Enumerated
+// fields such as App, Court or State take the same lower-case keys as the
+// CLI flags (e.g. "brkons", "vfgh", "wien"), case-insensitively. Dates are
+// given as JJJJ-MM-TT.
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/constants"
+)
+
+// Query is a search against one RIS endpoint.
+type Query interface {
+	// Endpoint returns the API endpoint the query is sent to, e.g.
+	// api.EndpointBundesrecht.
+	Endpoint() string
+	// Params validates the query and encodes it to API parameters.
+	Params() (*api.Params, error)
+}
+
+// Validate reports whether q can be sent, without keeping its encoding.
+func Validate(q Query) error {
+	_, err := q.Params()
+	return err
+}
+
+// Error reports a query that cannot be sent: a required field is missing or
+// a field has an invalid value. Fields are named like the Go struct fields
+// (e.g. "CaseNumber"); the CLI flag for a field is its kebab-case form
+// (FlagName).
+type Error struct {
+	// Fields names the offending fields. For a missing field error with
+	// several fields, at least one of them must be set.
+	Fields []string
+	// Value is the rejected value; empty if the field is missing.
+	Value string
+	// Valid lists the accepted values of an enumerated field.
+	Valid []string
+	// Expected describes the accepted format, e.g. "JJJJ-MM-TT".
+	Expected string
+	// Requires names the field, and RequiresValue its value, without which
+	// the offending fields are not accepted.
+	Requires      string
+	RequiresValue string
+}
+
+func (e *Error) Error() string {
+	return e.Format(func(field string) string { return field })
+}
+
+// Format renders the error with each field name passed through name, e.g.
+// to refer to CLI flags instead of struct fields.
+func (e *Error) Format(name func(field string) string) string {
+	names := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		names[i] = name(f)
+	}
+
+	if e.Requires != "" {
+		return fmt.Sprintf("%s nur mit %s %s möglich", strings.Join(names, " und "), name(e.Requires), e.RequiresValue)
+	}
+	if e.Value == "" {
+		switch {
+		case len(names) == 1:
+			return names[0] + " ist erforderlich"
+		case len(names) > 5:
+			return "mindestens ein Suchparameter erforderlich (" + strings.Join(names, ", ") + ")"
+		default:
+			return "mindestens " + strings.Join(names[:len(names)-1], ", ") + " oder " + names[len(names)-1] + " erforderlich"
+		}
+	}
+
+	msg := fmt.Sprintf("ungültiger %s Wert %q", strings.Join(names, ", "), e.Value)
+	switch {
+	case e.Expected != "":
+		msg += " (erwartet " + e.Expected + ")"
+	case len(e.Valid) > 4:
+		msg += "\nGültig: " + strings.Join(e.Valid, ", ")
+	case len(e.Valid) > 0:
+		msg += " (gültig: " + strings.Join(e.Valid, ", ") + ")"
+	}
+	return msg
+}
+
+// FlagName returns the CLI flag for a query field: "CaseNumber" becomes
+// "case-number".
+func FlagName(field string) string {
+	var sb strings.Builder
+	for i, r := range field {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// Paging selects a result page. The zero value requests the first page with
+// the API's default page size.
+type Paging struct {
+	// Page is the 1-based page number; 0 leaves it to the API.
+	Page int
+	// Size is the number of documents per page: 10, 20, 50 or 100; 0 leaves
+	// it to the API.
+	Size int
+}
+
+// Apply sets the paging parameters on params.
+func (p Paging) Apply(params *api.Params) error {
+	if p.Page < 0 {
+		return &Error{Fields: []string{"Page"}, Value: fmt.Sprint(p.Page), Expected: "eine positive Zahl"}
+	}
+	if p.Page > 0 {
+		params.Set("Seitennummer", fmt.Sprint(p.Page))
+	}
+	if p.Size != 0 {
+		size, ok := constants.PageSizes[p.Size]
+		if !ok {
+			return &Error{Fields: []string{"Size"}, Value: fmt.Sprint(p.Size), Valid: []string{"10", "20", "50", "100"}}
+		}
+		params.Set("DokumenteProSeite", size)
+	}
+	return nil
+}
+
+// Accepted values of shared enumerated fields, in the order they are listed
+// in error messages.
+var (
+	statesValid  = []string{"wien", "niederoesterreich", "oberoesterreich", "salzburg", "tirol", "vorarlberg", "kaernten", "steiermark", "burgenland"}
+	sinceValid   = []string{"einerwoche", "zweiwochen", "einemmonat", "dreimonaten", "sechsmonaten", "einemjahr"}
+	sortDirValid = []string{"asc", "desc"}
+)
+
+// encoder builds the parameters of a query and records the first
+// validation error; later calls are no-ops once an error is recorded.
+type encoder struct {
+	params *api.Params
+	err    error
+}
+
+func newEncoder() *encoder {
+	return &encoder{params: api.NewParams()}
+}
+
+// result returns the encoded parameters or the first error.
+func (e *encoder) result() (*api.Params, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return e.params, nil
+}
+
+// require fails unless at least one of values is set. fields names the
+// corresponding query fields.
+func (e *encoder) require(fields []string, values ...string) {
+	if e.err != nil {
+		return
+	}
+	for _, v := range values {
+		if v != "" {
+			return
+		}
+	}
+	e.err = &Error{Fields: fields}
+}
+
+// onlyWith fails if any of values is set: the corresponding fields are only
+// accepted with field set to value.
+func (e *encoder) onlyWith(fields, values []string, field, value string) {
+	if e.err != nil {
+		return
+	}
+	var set []string
+	for i, v := range values {
+		if v != "" {
+			set = append(set, fields[i])
+		}
+	}
+	if len(set) > 0 {
+		e.err = &Error{Fields: set, Requires: field, RequiresValue: value}
+	}
+}
+
+// set sets key if value is not empty.
+func (e *encoder) set(key, value string) {
+	if e.err == nil && value != "" {
+		e.params.Set(key, value)
+	}
+}
+
+// lookup resolves an enumerated field through table and sets key to the
+// API value. An empty value is skipped.
+func (e *encoder) lookup(field, key, value string, table map[string]string, valid []string) {
+	if e.err != nil || value == "" {
+		return
+	}
+	apiValue, ok := table[strings.ToLower(value)]
+	if !ok {
+		e.err = &Error{Fields: []string{field}, Value: value, Valid: valid}
+		return
+	}
+	e.params.Set(key, apiValue)
+}
+
+// date sets key to a JJJJ-MM-TT date. An empty value is skipped.
+func (e *encoder) date(field, key, value string) {
+	if e.err != nil || value == "" {
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, value); err != nil {
+		e.err = &Error{Fields: []string{field}, Value: value, Expected: "JJJJ-MM-TT"}
+		return
+	}
+	e.params.Set(key, value)
+}
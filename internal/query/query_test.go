@@ -0,0 +1,225 @@
+package query
+
+import (
+	"errors"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/constants"
+)
+
+func TestParams_Encoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    Query
+		endpoint string
+		want     url.Values
+	}{
+		{
+			name:     "bundesrecht paragraph",
+			query:    BundesrechtQuery{Title: "ABGB", Paragraph: "1295", Date: "2024-01-15"},
+			endpoint: api.EndpointBundesrecht,
+			want: url.Values{
+				"Applikation": {"BrKons"}, "Titel": {"ABGB"}, "FassungVom": {"2024-01-15"},
+				"Abschnitt.Von": {"1295"}, "Abschnitt.Bis": {"1295"}, "Abschnitt.Typ": {"Paragraph"},
+			},
+		},
+		{
+			name:     "bundesrecht metadata filters",
+			query:    BundesrechtQuery{LawNumber: "10001622", Type: "BG", Index: "20/01", Keywords: "Schadenersatz"},
+			endpoint: api.EndpointBundesrecht,
+			want: url.Values{
+				"Applikation": {"BrKons"}, "Gesetzesnummer": {"10001622"}, "Typ": {"BG"}, "Index": {"20/01"}, "Schlagworte": {"Schadenersatz"},
+			},
+		},
+		{
+			name:     "bgbl",
+			query:    BgblQuery{App: "BgblPdf", Number: "120", Year: "2023", Part: "1"},
+			endpoint: api.EndpointBundesrecht,
+			want:     url.Values{"Applikation": {"BgblPdf"}, "Bgblnummer": {"120"}, "Jahrgang": {"2023"}, "Teil": {"Eins"}},
+		},
+		{
+			name:     "regvorl",
+			query:    RegvorlQuery{Ministry: "bmf", Since: "einemmonat", SortDir: "desc", SortBy: "datum"},
+			endpoint: api.EndpointBundesrecht,
+			want: url.Values{
+				"Applikation": {"RegV"}, "EinbringendeStelle": {constants.RegvorlMinistries["bmf"]}, "ImRisSeit": {"EinemMonat"},
+				"Sortierung.SortDirection": {"Descending"}, "Sortierung.SortedByColumn": {"Beschlussdatum"},
+			},
+		},
+		{
+			name:     "judikatur",
+			query:    JudikaturQuery{Court: "vfgh", Norm: "1319a ABGB", From: "2020-01-01", To: "2024-12-31"},
+			endpoint: api.EndpointJudikatur,
+			want: url.Values{
+				"Applikation": {"Vfgh"}, "Norm": {"1319a ABGB"},
+				"EntscheidungsdatumVon": {"2020-01-01"}, "EntscheidungsdatumBis": {"2024-12-31"},
+			},
+		},
+		{
+			name:     "landesrecht state switch",
+			query:    LandesrechtQuery{Search: "Bauordnung", State: "Salzburg"},
+			endpoint: api.EndpointLandesrecht,
+			want:     url.Values{"Applikation": {"LrKons"}, "Suchworte": {"Bauordnung"}, "Bundesland.SucheInSalzburg": {"true"}},
+		},
+		{
+			name:     "verordnungen state name",
+			query:    VerordnungenQuery{State: "kaernten"},
+			endpoint: api.EndpointLandesrecht,
+			want:     url.Values{"Applikation": {"Vbl"}, "Bundesland": {"Kärnten"}},
+		},
+		{
+			name:     "history",
+			query:    HistoryQuery{App: "Justiz", From: "2024-06-01", IncludeDeleted: true},
+			endpoint: api.EndpointHistory,
+			want:     url.Values{"Anwendung": {"justiz"}, "AenderungenVon": {"2024-06-01"}, "IncludeDeletedDocuments": {"true"}},
+		},
+		{
+			name:     "gemeinden",
+			query:    GemeindenQuery{App: "gra", Municipality: "Graz", From: "2024-01-01"},
+			endpoint: api.EndpointGemeinden,
+			want:     url.Values{"Applikation": {"GrA"}, "Gemeinde": {"Graz"}, "Kundmachungsdatum.Von": {"2024-01-01"}},
+		},
+		{
+			name:     "sonstige common fields",
+			query:    MrpQuery{Sonstige: Sonstige{Search: "Budget", SortDir: "asc"}, Session: "42"},
+			endpoint: api.EndpointSonstige,
+			want: url.Values{
+				"Applikation": {"Mrp"}, "Suchworte": {"Budget"}, "Sortierung.SortDirection": {"Ascending"},
+				"Sitzungsnummer": {"42"},
+			},
+		},
+		{
+			name:     "spg",
+			query:    SpgQuery{OsgType: "oesg-grossgeraete"},
+			endpoint: api.EndpointSonstige,
+			want:     url.Values{"Applikation": {"Spg"}, "OsgTyp": {"ÖSG - Großgeräteplan"}},
+		},
+		{
+			name:     "document",
+			query:    DocumentQuery{Number: "JWR_2020010001"},
+			endpoint: api.EndpointJudikatur,
+			want:     url.Values{"Applikation": {"Vwgh"}, "Dokumentnummer": {"JWR_2020010001"}, "DokumenteProSeite": {"Ten"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := tt.query.Params()
+			if err != nil {
+				t.Fatalf("Params() error: %v", err)
+			}
+			if got := params.Values(); !maps.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("Params() = %v, want %v", got, tt.want)
+			}
+			if got := tt.query.Endpoint(); got != tt.endpoint {
+				t.Errorf("Endpoint() = %q, want %q", got, tt.endpoint)
+			}
+		})
+	}
+}
+
+func TestParams_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   Query
+		wantErr string
+	}{
+		{"missing search field", BundesrechtQuery{Date: "2024-01-01", Type: "BG"}, "mindestens ein Suchparameter erforderlich (Search, Title, Paragraph, LawNumber, Index, Keywords)"},
+		{"missing before invalid app", BundesrechtQuery{App: "invalid"}, "mindestens ein Suchparameter erforderlich (Search, Title, Paragraph, LawNumber, Index, Keywords)"},
+		{"invalid app", BundesrechtQuery{Search: "x", App: "invalid"}, `ungültiger App Wert "invalid" (gültig: brkons, begut, bgblauth, erv)`},
+		{"type only with brkons", BundesrechtQuery{App: "begut", Search: "x", Type: "BG"}, "Type nur mit App brkons möglich"},
+		{"type and index only with brkons", BundesrechtQuery{App: "erv", Index: "20/01", Type: "BG"}, "Type und Index nur mit App brkons möglich"},
+		{"invalid court", JudikaturQuery{Search: "x", Court: "ogh"}, "ungültiger Court Wert \"ogh\"\nGültig: justiz, vfgh"},
+		{"invalid date", JudikaturQuery{Search: "x", From: "15.01.2024"}, `ungültiger From Wert "15.01.2024" (erwartet JJJJ-MM-TT)`},
+		{"history app required", HistoryQuery{From: "2024-01-01"}, "App ist erforderlich"},
+		{"history range required", HistoryQuery{App: "justiz"}, "mindestens From oder To erforderlich"},
+		{"history invalid app", HistoryQuery{App: "bundesrecht", To: "2024-01-01"}, `ungültiger App Wert "bundesrecht"`},
+		{"gemeinden many fields", GemeindenQuery{}, "mindestens ein Suchparameter erforderlich (Search, Title,"},
+		{"invalid state", LgblQuery{State: "bayern"}, `ungültiger State Wert "bayern"`},
+		{"sonstige since", ErlaesseQuery{Sonstige: Sonstige{Since: "gestern"}}, `ungültiger Since Wert "gestern"`},
+		{"document number", DocumentQuery{}, "Number ist erforderlich"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.query)
+			var qErr *Error
+			if !errors.As(err, &qErr) {
+				t.Fatalf("expected *Error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestError_FormatFlags(t *testing.T) {
+	err := &Error{Fields: []string{"Search", "CaseNumber"}}
+	got := err.Format(func(field string) string { return "--" + FlagName(field) })
+	if want := "mindestens --search oder --case-number erforderlich"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestFlagName(t *testing.T) {
+	tests := map[string]string{
+		"Search":         "search",
+		"CaseNumber":     "case-number",
+		"AnnouncementNr": "announcement-nr",
+		"OsgType":        "osg-type",
+		"SortDir":        "sort-dir",
+	}
+	for field, want := range tests {
+		if got := FlagName(field); got != want {
+			t.Errorf("FlagName(%q) = %q, want %q", field, got, want)
+		}
+	}
+}
+
+// TestValidLists ensures the values listed in error messages match the
+// lookup tables.
+func TestValidLists(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid []string
+		table map[string]string
+	}{
+		{"states", statesValid, constants.LandesrechtStates},
+		{"since", sinceValid, constants.ImRisSeit},
+		{"sort-dir", sortDirValid, constants.SortDirections},
+	}
+	for _, tt := range tests {
+		got := slices.Sorted(maps.Keys(tt.table))
+		if want := slices.Sorted(slices.Values(tt.valid)); !slices.Equal(got, want) {
+			t.Errorf("%s: table keys %v, listed %v", tt.name, got, want)
+		}
+	}
+	for _, app := range historyAppsValid {
+		if !constants.IsValidHistoryApp(app) {
+			t.Errorf("history app %q is listed but not valid", app)
+		}
+	}
+}
+
+func TestPaging(t *testing.T) {
+	params := api.NewParams()
+	if err := (Paging{Page: 3, Size: 50}).Apply(params); err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	if params.Get("Seitennummer") != "3" || params.Get("DokumenteProSeite") != "Fifty" {
+		t.Errorf("unexpected params: %s", params.Encode())
+	}
+
+	params = api.NewParams()
+	if err := (Paging{}).Apply(params); err != nil || params.Encode() != "" {
+		t.Errorf("zero Paging: err=%v params=%q, want no params", err, params.Encode())
+	}
+
+	if err := (Paging{Size: 25}).Apply(api.NewParams()); err == nil || !strings.Contains(err.Error(), "ungültiger Size Wert") {
+		t.Errorf("expected error for Size 25, got %v", err)
+	}
+}
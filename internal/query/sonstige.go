@@ -0,0 +1,254 @@
+package query
+
+import (
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/constants"
+)
+
+// Sonstige holds the fields shared by the queries of the Sonstige
+// applications (MrpQuery, ErlaesseQuery, …). None of them is required.
+type Sonstige struct {
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// Since restricts to documents added to RIS within a period, e.g.
+	// einemmonat.
+	Since string
+	// SortDir is the sort direction: asc or desc.
+	SortDir string
+}
+
+// encoder starts the parameters of a Sonstige application.
+func (s Sonstige) encoder(applikation string) *encoder {
+	e := newEncoder()
+	e.set("Applikation", applikation)
+	e.set("Suchworte", s.Search)
+	e.set("Titel", s.Title)
+	e.lookup("Since", "ImRisSeit", s.Since, constants.ImRisSeit, sinceValid)
+	e.lookup("SortDir", "Sortierung.SortDirection", s.SortDir, constants.SortDirections, sortDirValid)
+	return e
+}
+
+// MrpQuery searches the minutes of the Council of Ministers
+// (Ministerratsprotokolle).
+type MrpQuery struct {
+	Sonstige
+	// From and To bound the session date (JJJJ-MM-TT).
+	From, To string
+	// Submitter is the submitting ministry.
+	Submitter string
+	// Session is the session number.
+	Session string
+	// Period is the legislative period.
+	Period string
+	// FileNumber is the file number (Geschäftszahl).
+	FileNumber string
+}
+
+// Endpoint implements Query.
+func (q MrpQuery) Endpoint() string { return api.EndpointSonstige }
+
+// Params implements Query.
+func (q MrpQuery) Params() (*api.Params, error) {
+	e := q.encoder("Mrp")
+	e.date("From", "Sitzungsdatum.Von", q.From)
+	e.date("To", "Sitzungsdatum.Bis", q.To)
+	e.set("Einbringer", q.Submitter)
+	e.set("Sitzungsnummer", q.Session)
+	e.set("Gesetzgebungsperiode", q.Period)
+	e.set("Geschaeftszahl", q.FileNumber)
+	return e.result()
+}
+
+// ErlaesseQuery searches ministerial decrees (Erlässe).
+type ErlaesseQuery struct {
+	Sonstige
+	// From and To bound the date of entry into force (JJJJ-MM-TT).
+	From, To string
+	// Ministry is the issuing ministry, e.g. bmf.
+	Ministry string
+	// Department is the department (Abteilung).
+	Department string
+	// Source is the publication reference (Fundstelle).
+	Source string
+	// Norm searches cited norms.
+	Norm string
+	// Date selects the version in force on that date (JJJJ-MM-TT).
+	Date string
+}
+
+// Endpoint implements Query.
+func (q ErlaesseQuery) Endpoint() string { return api.EndpointSonstige }
+
+// Params implements Query.
+func (q ErlaesseQuery) Params() (*api.Params, error) {
+	e := q.encoder("Erlaesse")
+	e.date("From", "VonInkrafttretensdatum", q.From)
+	e.date("To", "BisInkrafttretensdatum", q.To)
+	e.lookup("Ministry", "Bundesministerium", q.Ministry, constants.ErlMinistries,
+		[]string{"bka", "bmkoes", "bmeia", "bmaw", "bmbwf", "bmf", "bmi", "bmj", "bmk", "bmlv", "bml", "bmsgpk"})
+	e.set("Abteilung", q.Department)
+	e.set("Fundstelle", q.Source)
+	e.set("Norm", q.Norm)
+	e.date("Date", "FassungVom", q.Date)
+	return e.result()
+}
+
+// UptsQuery searches party transparency decisions (UPTS).
+type UptsQuery struct {
+	Sonstige
+	// From and To bound the decision date (JJJJ-MM-TT).
+	From, To string
+	// Party is the political party: spoe, oevp, fpoe, gruene, neos or bzoe.
+	Party string
+	// FileNumber is the file number (Geschäftszahl).
+	FileNumber string
+	// Norm searches cited norms.
+	Norm string
+}
+
+// Endpoint implements Query.
+func (q UptsQuery) Endpoint() string { return api.EndpointSonstige }
+
+// Params implements Query.
+func (q UptsQuery) Params() (*api.Params, error) {
+	e := q.encoder("Upts")
+	e.date("From", "Entscheidungsdatum.Von", q.From)
+	e.date("To", "Entscheidungsdatum.Bis", q.To)
+	e.lookup("Party", "Partei", q.Party, constants.UptsParties, []string{"spoe", "oevp", "fpoe", "gruene", "neos", "bzoe"})
+	e.set("Geschaeftszahl", q.FileNumber)
+	e.set("Norm", q.Norm)
+	return e.result()
+}
+
+// KmgerQuery searches court announcements (KmGer).
+type KmgerQuery struct {
+	Sonstige
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// Type is the announcement type: geschaeftsordnung or
+	// geschaeftsverteilung.
+	Type string
+	// CourtName is the court.
+	CourtName string
+	// FileNumber is the file number (Geschäftszahl).
+	FileNumber string
+}
+
+// Endpoint implements Query.
+func (q KmgerQuery) Endpoint() string { return api.EndpointSonstige }
+
+// Params implements Query.
+func (q KmgerQuery) Params() (*api.Params, error) {
+	e := q.encoder("KmGer")
+	e.date("From", "Kundmachungsdatum.Von", q.From)
+	e.date("To", "Kundmachungsdatum.Bis", q.To)
+	e.lookup("Type", "Typ", q.Type, constants.KmgerTypes, []string{"geschaeftsordnung", "geschaeftsverteilung"})
+	e.set("Gericht", q.CourtName)
+	e.set("Geschaeftszahl", q.FileNumber)
+	return e.result()
+}
+
+// AvsvQuery searches social insurance announcements (AVSV).
+type AvsvQuery struct {
+	Sonstige
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// DocType is the document type (Dokumentart).
+	DocType string
+	// Author is the issuing institution: dvsv, pva, oegk, auva, svs or
+	// bvaeb.
+	Author string
+	// AvsvNumber is the AVSV number.
+	AvsvNumber string
+}
+
+// Endpoint implements Query.
+func (q AvsvQuery) Endpoint() string { return api.EndpointSonstige }
+
+// Params implements Query.
+func (q AvsvQuery) Params() (*api.Params, error) {
+	e := q.encoder("Avsv")
+	e.date("From", "Kundmachung.Von", q.From)
+	e.date("To", "Kundmachung.Bis", q.To)
+	e.set("Dokumentart", q.DocType)
+	e.lookup("Author", "Urheber", q.Author, constants.AvsvAuthors, []string{"dvsv", "pva", "oegk", "auva", "svs", "bvaeb"})
+	e.set("Avsvnummer", q.AvsvNumber)
+	return e.result()
+}
+
+// AvnQuery searches veterinary announcements (AVN).
+type AvnQuery struct {
+	Sonstige
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// AvnNumber is the AVN number.
+	AvnNumber string
+	// Type is the announcement type: kundmachung, verordnung or erlass.
+	Type string
+}
+
+// Endpoint implements Query.
+func (q AvnQuery) Endpoint() string { return api.EndpointSonstige }
+
+// Params implements Query.
+func (q AvnQuery) Params() (*api.Params, error) {
+	e := q.encoder("Avn")
+	e.date("From", "Kundmachung.Von", q.From)
+	e.date("To", "Kundmachung.Bis", q.To)
+	e.set("Avnnummer", q.AvnNumber)
+	e.lookup("Type", "Typ", q.Type, constants.AvnTypes, []string{"kundmachung", "verordnung", "erlass"})
+	return e.result()
+}
+
+// SpgQuery searches health structure plans (SPG).
+type SpgQuery struct {
+	Sonstige
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// SpgNumber is the SPG number.
+	SpgNumber string
+	// OsgType is the ÖSG type: oesg or oesg-grossgeraete.
+	OsgType string
+	// RsgType is the RSG type: rsg or rsg-grossgeraete.
+	RsgType string
+	// RsgState is the state of a regional plan (RSG).
+	RsgState string
+}
+
+// Endpoint implements Query.
+func (q SpgQuery) Endpoint() string { return api.EndpointSonstige }
+
+// Params implements Query.
+func (q SpgQuery) Params() (*api.Params, error) {
+	e := q.encoder("Spg")
+	e.date("From", "Kundmachungsdatum.Von", q.From)
+	e.date("To", "Kundmachungsdatum.Bis", q.To)
+	e.set("Spgnummer", q.SpgNumber)
+	e.lookup("OsgType", "OsgTyp", q.OsgType, constants.OsgTypes, []string{"oesg", "oesg-grossgeraete"})
+	e.lookup("RsgType", "RsgTyp", q.RsgType, constants.RsgTypes, []string{"rsg", "rsg-grossgeraete"})
+	e.set("RsgLand", q.RsgState)
+	return e.result()
+}
+
+// PruefGewOQuery searches trade examinations (PrüfGewO).
+type PruefGewOQuery struct {
+	Sonstige
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// Type is the examination type: befaehigung, eignung or meister.
+	Type string
+}
+
+// Endpoint implements Query.
+func (q PruefGewOQuery) Endpoint() string { return api.EndpointSonstige }
+
+// Params implements Query.
+func (q PruefGewOQuery) Params() (*api.Params, error) {
+	e := q.encoder("PruefGewO")
+	e.date("From", "Kundmachungsdatum.Von", q.From)
+	e.date("To", "Kundmachungsdatum.Bis", q.To)
+	e.lookup("Type", "Typ", q.Type, constants.PruefgewoTypes, []string{"befaehigung", "eignung", "meister"})
+	return e.result()
+}
@@ -1,21 +1,27 @@
 package main
 
 import (
-	"errors"
-	"fmt"
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/philrox/risgo/cmd"
 )
 
 func main() {
-	if err := cmd.Execute(); err != nil {
-		var ve *cmd.ValidationError
-		if errors.As(err, &ve) {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(2)
-		}
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	// Cancel in-flight requests on Ctrl-C / SIGTERM. After the first signal the
+	// default handling is restored, so a second Ctrl-C terminates immediately.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := cmd.Execute(ctx)
+	stop()
+	if err != nil {
+		cmd.WriteError(os.Stderr, err)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -0,0 +1,265 @@
+package ris
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/query"
+)
+
+// QueryError reports a query that cannot be sent: a required field is
+// missing or a field has an invalid value. Fields are named like the Go
+// struct fields (e.g. "CaseNumber").
+type QueryError struct {
+	// Fields names the offending fields. For a missing field error with
+	// several fields, at least one of them must be set.
+	Fields []string
+	// Value is the rejected value; empty if the field is missing.
+	Value string
+	// Valid lists the accepted values of an enumerated field.
+	Valid []string
+	// Expected describes the accepted format, e.g. "JJJJ-MM-TT".
+	Expected string
+	// Requires names the field, and RequiresValue its value, without which
+	// the offending fields are not accepted.
+	Requires      string
+	RequiresValue string
+}
+
+func (e *QueryError) Error() string {
+	return (*query.Error)(e).Error()
+}
+
+// Format renders the error with each field name passed through name, e.g.
+// to refer to form fields of a service instead of struct fields.
+func (e *QueryError) Format(name func(field string) string) string {
+	return (*query.Error)(e).Format(name)
+}
+
+// APIError is a rejection reported by the RIS API itself, e.g. for an
+// invalid parameter combination. The API reports these with HTTP 200.
+type APIError struct {
+	// Applikation is the collection the error refers to, if given.
+	Applikation string
+	// Message is the server's error message.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return (*api.APIError)(e).Error()
+}
+
+// HTTPError is a response with a non-2xx status code.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	URL        string
+	Attempts   int
+	// RetryAfter is the delay the server asked for before a retry, if the
+	// client gave up instead of waiting that long.
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return (*api.HTTPError)(e).Error()
+}
+
+// Retryable reports whether the status indicates a transient server
+// condition (429, 5xx).
+func (e *HTTPError) Retryable() bool {
+	return (*api.HTTPError)(e).Retryable()
+}
+
+// TimeoutError is a request that exceeded Options.Timeout.
+type TimeoutError struct {
+	URL      string
+	Err      error
+	Attempts int
+}
+
+func (e *TimeoutError) Error() string {
+	return (*api.TimeoutError)(e).Error()
+}
+
+// Retryable reports true: a timed out request may succeed later.
+func (e *TimeoutError) Retryable() bool { return true }
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// RequestError is a request that failed before a response arrived, e.g.
+// because the connection was refused.
+type RequestError struct {
+	URL      string
+	Err      error
+	Attempts int
+}
+
+func (e *RequestError) Error() string {
+	return (*api.RequestError)(e).Error()
+}
+
+// Retryable reports whether the failure may be transient.
+func (e *RequestError) Retryable() bool {
+	return (*api.RequestError)(e).Retryable()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// RedirectError is a redirect that was refused because its target is not
+// allowed or the redirect chain is too long.
+type RedirectError struct {
+	URL    string
+	Reason string
+}
+
+func (e *RedirectError) Error() string {
+	return (*api.RedirectError)(e).Error()
+}
+
+// ResponseTooLargeError is a response exceeding Options.MaxResponseSize.
+// Retrying does not help.
+type ResponseTooLargeError struct {
+	URL   string
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return (*api.ResponseTooLargeError)(e).Error()
+}
+
+// URLError is a document URL that was refused before any request was made:
+// it is malformed, not HTTPS, or points to a host that is not allowed (see
+// Options.AllowedHosts).
+type URLError struct {
+	URL    string
+	Reason string
+}
+
+func (e *URLError) Error() string {
+	return (*api.URLError)(e).Error()
+}
+
+// ContentTypeError is a document response with a different Content-Type
+// than requested, e.g. an HTML error page instead of a PDF.
+type ContentTypeError struct {
+	URL         string
+	ContentType string
+	Want        []string
+}
+
+func (e *ContentTypeError) Error() string {
+	return (*api.ContentTypeError)(e).Error()
+}
+
+// OfflineError is a request in offline mode whose response is not in the
+// cache.
+type OfflineError struct {
+	URL string
+}
+
+func (e *OfflineError) Error() string {
+	return (*api.OfflineError)(e).Error()
+}
+
+// ReplayMissError is a request in replay mode that no recording matches.
+type ReplayMissError struct {
+	URL string
+	Dir string
+}
+
+func (e *ReplayMissError) Error() string {
+	return (*api.ReplayMissError)(e).Error()
+}
+
+// PageError is a page that failed during HistoryPagesConcurrent; the
+// iteration continues with the following pages.
+type PageError struct {
+	Page int
+	Err  error
+}
+
+func (e *PageError) Error() string {
+	return (&api.PageError{Page: e.Page, Err: e.Err}).Error()
+}
+
+func (e *PageError) Unwrap() error { return e.Err }
+
+// publicError replaces the internal error types in err's chain by the ones
+// declared in this package, so that errors.As works with the latter.
+// Errors wrapping an internal error keep their message.
+func publicError(err error) error {
+	if pub, ok := convertError(err); ok {
+		return pub
+	}
+	return err
+}
+
+// convertError implements publicError and reports whether err's chain
+// contained an internal error.
+func convertError(err error) (error, bool) {
+	switch e := err.(type) {
+	case nil:
+		return nil, false
+	case *query.Error:
+		return (*QueryError)(e), true
+	case *api.APIError:
+		return (*APIError)(e), true
+	case *api.HTTPError:
+		return (*HTTPError)(e), true
+	case *api.TimeoutError:
+		return &TimeoutError{URL: e.URL, Err: publicError(e.Err), Attempts: e.Attempts}, true
+	case *api.RequestError:
+		return &RequestError{URL: e.URL, Err: publicError(e.Err), Attempts: e.Attempts}, true
+	case *api.RedirectError:
+		return (*RedirectError)(e), true
+	case *api.ResponseTooLargeError:
+		return (*ResponseTooLargeError)(e), true
+	case *api.URLError:
+		return (*URLError)(e), true
+	case *api.ContentTypeError:
+		return (*ContentTypeError)(e), true
+	case *api.OfflineError:
+		return (*OfflineError)(e), true
+	case *api.ReplayMissError:
+		return (*ReplayMissError)(e), true
+	case *api.PageError:
+		return &PageError{Page: e.Page, Err: publicError(e.Err)}, true
+	}
+
+	pub, ok := convertError(errors.Unwrap(err))
+	if !ok {
+		return err, false
+	}
+	return &wrapError{msg: err.Error(), err: pub}, true
+}
+
+// wrapError keeps the message of an error whose wrapped error publicError
+// replaced.
+type wrapError struct {
+	msg string
+	err error
+}
+
+func (e *wrapError) Error() string { return e.msg }
+
+func (e *wrapError) Unwrap() error { return e.err }
+
+// body converts the read errors of a document stream, e.g. a
+// *ResponseTooLargeError once the body exceeds Options.MaxResponseSize.
+type body struct {
+	io.ReadCloser
+}
+
+func (b body) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != io.EOF {
+		err = publicError(err)
+	}
+	return n, err
+}
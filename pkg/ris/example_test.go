@@ -0,0 +1,120 @@
+package ris_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/philrox/risgo/pkg/ris"
+	"github.com/philrox/risgo/pkg/ristest"
+)
+
+// newTestClient returns a client for a fake API server. Real code uses
+// ris.NewClient(ris.Options{UserAgent: "myservice/1.0"}).
+func newTestClient() (*ris.Client, func()) {
+	srv, err := ristest.NewServer(ristest.Options{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	client, err := ris.NewClient(ris.Options{BaseURL: srv.BaseURL()})
+	if err != nil {
+		log.Fatal(err)
+	}
+	return client, srv.Close
+}
+
+func Example() {
+	client, done := newTestClient()
+	defer done()
+
+	result, err := client.Search(context.Background(), ris.BundesrechtQuery{Title: "ABGB"}, ris.Paging{Size: 10})
+	if err != nil {
+		log.Fatal(err)
+	}
+	doc := result.Documents[0]
+	fmt.Println(result.TotalHits, "Treffer")
+	fmt.Println(doc.Dokumentnummer, ris.FormatCitation(doc.Citation))
+	// Output:
+	// 23 Treffer
+	// NOR12017681 § 1 ABGB (JGS Nr. 946/1811)
+}
+
+func ExampleClient_SearchAll() {
+	client, done := newTestClient()
+	defer done()
+
+	q := ris.JudikaturQuery{Court: "justiz", Search: "Schadenersatz"}
+	n := 0
+	for doc, err := range client.SearchAll(context.Background(), q, ris.Paging{Size: 10}) {
+		if err != nil {
+			log.Fatal(err)
+		}
+		if n == 0 {
+			fmt.Println(doc.Geschaeftszahl)
+		}
+		n++
+	}
+	fmt.Println("Entscheidungen:", n)
+	// Output:
+	// 1Ob1/24a
+	// Entscheidungen: 1
+}
+
+func ExampleClient_OpenDocument() {
+	client, done := newTestClient()
+	defer done()
+
+	body, err := client.OpenDocument(context.Background(), "NOR12017681")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer body.Close()
+
+	var text strings.Builder
+	if err := ris.WriteHTMLText(&text, body); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(strings.SplitN(text.String(), "\n", 2)[0])
+	// Output:
+	// ABGB § 1
+}
+
+func ExampleQueryError() {
+	_, err := ris.BundesrechtQuery{Search: "Mietrecht", App: "bgbl"}.Params()
+
+	var qErr *ris.QueryError
+	if errors.As(err, &qErr) {
+		fmt.Println(qErr.Fields, qErr.Value)
+		fmt.Println(err)
+	}
+	// Output:
+	// [App] bgbl
+	// ungültiger App Wert "bgbl" (gültig: brkons, begut, bgblauth, erv)
+}
+
+func ExampleDirectURL() {
+	fmt.Println(ris.DirectURL("NOR12017681"))
+	// Output:
+	// https://ris.bka.gv.at/Dokumente/Bundesnormen/NOR12017681/NOR12017681.html
+}
+
+func ExampleClient_HistoryPages() {
+	client, done := newTestClient()
+	defer done()
+
+	q := ris.HistoryQuery{App: "bundesnormen", From: "2024-06-01", IncludeDeleted: true}
+	for page, err := range client.HistoryPages(context.Background(), q, ris.Paging{Size: 10}) {
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, e := range page.Events {
+			if e.Aenderung == ris.ChangeDeleted {
+				fmt.Println(e.Dokumentnummer, "gelöscht am", e.Day())
+			}
+		}
+	}
+	// Output:
+	// NOR12017683 gelöscht am 2024-07-01
+}
@@ -0,0 +1,45 @@
+package ris
+
+import (
+	"io"
+
+	"github.com/philrox/risgo/internal/format"
+	"github.com/philrox/risgo/internal/model"
+)
+
+// WriteText writes a result page as human-readable text, like the CLI's
+// search output. Colors follow github.com/fatih/color (off unless w is a
+// terminal; see color.NoColor).
+func WriteText(w io.Writer, result SearchResult) error {
+	return format.Text(w, result.internal())
+}
+
+// WriteJSON writes a result page as indented JSON, like "risgo --json".
+func WriteJSON(w io.Writer, result SearchResult) error {
+	return format.JSON(w, result.internal())
+}
+
+// WriteDocumentText writes a document's metadata followed by its text
+// content, like "risgo dokument".
+func WriteDocumentText(w io.Writer, doc Document, content string) error {
+	return format.TextDocument(w, doc.internal(), content)
+}
+
+// WriteDocumentJSON writes a document's metadata and text content as
+// indented JSON, like "risgo dokument --json".
+func WriteDocumentJSON(w io.Writer, doc Document, content string) error {
+	return format.JSONDocument(w, doc.internal(), content)
+}
+
+// WriteHTMLText converts document HTML read from r to plain text and writes
+// it to w as it goes. Scripts, styles and the document head are dropped and
+// whitespace is normalized.
+func WriteHTMLText(w io.Writer, r io.Reader) error {
+	return format.WriteHTMLText(w, r)
+}
+
+// FormatCitation formats a citation the Austrian way, e.g.
+// "§ 1295 ABGB (JGS Nr. 946/1811)". It returns "" for nil.
+func FormatCitation(c *Citation) string {
+	return format.FormatCitation((*model.Citation)(c))
+}
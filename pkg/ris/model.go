@@ -0,0 +1,298 @@
+package ris
+
+import (
+	"github.com/philrox/risgo/internal/model"
+)
+
+// SearchResult is one page of search results.
+type SearchResult struct {
+	TotalHits int        `json:"total_hits"`
+	Page      int        `json:"page"`
+	PageSize  int        `json:"page_size"`
+	HasMore   bool       `json:"has_more"`
+	Documents []Document `json:"documents"`
+}
+
+// Document is a document reference with its metadata and content URLs.
+// Which metadata fields are set depends on the collection.
+type Document struct {
+	Dokumentnummer             string      `json:"dokumentnummer"`
+	Applikation                string      `json:"applikation"`
+	Titel                      string      `json:"titel"`
+	Kurztitel                  string      `json:"kurztitel"`
+	Citation                   *Citation   `json:"citation,omitempty"`
+	ContentURLs                ContentURLs `json:"content_urls"`
+	DokumentURL                string      `json:"dokument_url"`
+	GesamteRechtsvorschriftURL string      `json:"gesamte_rechtsvorschrift_url,omitempty"`
+	Geschaeftszahl             string      `json:"geschaeftszahl,omitempty"`
+	Leitsatz                   string      `json:"leitsatz,omitempty"`
+
+	// Consolidated federal and state law. Schlagworte is shared with court
+	// decisions.
+	Gesetzesnummer string   `json:"gesetzesnummer,omitempty"` // e.g. "10001622" for the ABGB
+	Normtyp        string   `json:"normtyp,omitempty"`        // e.g. "BG", "V", "K"
+	Indizes        []string `json:"indizes,omitempty"`        // subject index (Sachgebiet), e.g. "20/01 Allgemeines bürgerliches Recht"
+	Aenderungen    []string `json:"aenderungen,omitempty"`    // amending gazette references, e.g. "BGBl. I Nr. 87/2015"
+
+	// Court decisions (Judikatur).
+	Geschaeftszahlen  []string `json:"geschaeftszahlen,omitempty"`  // all case numbers (also Sonstige, Gemeinden), the first is Geschaeftszahl
+	Dokumenttyp       string   `json:"dokumenttyp,omitempty"`       // "Rechtssatz" or "Entscheidungstext"
+	Entscheidungsart  string   `json:"entscheidungsart,omitempty"`  // e.g. "Erkenntnis", "Beschluss"
+	Normen            []string `json:"normen,omitempty"`            // cited norms, e.g. "ABGB §1096"
+	Schlagworte       []string `json:"schlagworte,omitempty"`       // keywords, also set for laws
+	Rechtssatznummern []string `json:"rechtssatznummern,omitempty"` // headnotes, e.g. "RS0012345"
+
+	// Other publications (Sonstige). Geschaeftszahl and Normen are shared
+	// with court decisions.
+	Typ                  string `json:"typ,omitempty"`                  // kind of publication, e.g. "Satzung", "Erlass"
+	Sitzungsdatum        string `json:"sitzungsdatum,omitempty"`        // Mrp: date of the Council of Ministers session
+	Sitzungsnummer       string `json:"sitzungsnummer,omitempty"`       // Mrp
+	Gesetzgebungsperiode string `json:"gesetzgebungsperiode,omitempty"` // Mrp: legislative period
+	Einbringer           string `json:"einbringer,omitempty"`           // Mrp: submitting ministry
+	Bundesministerium    string `json:"bundesministerium,omitempty"`    // Erlaesse: issuing ministry
+	Abteilung            string `json:"abteilung,omitempty"`            // Erlaesse: department
+	Fundstelle           string `json:"fundstelle,omitempty"`           // Erlaesse: publication reference
+	Partei               string `json:"partei,omitempty"`               // Upts: political party
+	Urheber              string `json:"urheber,omitempty"`              // Avsv: issuing institution
+	Nummer               string `json:"nummer,omitempty"`               // AVSV, AVN or SPG number
+	Bundesland           string `json:"bundesland,omitempty"`           // state of a regional plan (Spg) or a local ordinance
+
+	// District and municipal law (Bezirke, Gemeinden).
+	Behoerde string `json:"behoerde,omitempty"` // issuing district authority, e.g. "BH Mödling"
+	Gemeinde string `json:"gemeinde,omitempty"` // municipality or association of municipalities
+	Bezirk   string `json:"bezirk,omitempty"`   // district of a municipal gazette (GrA)
+}
+
+// Citation holds the structured citation data of a document. Use
+// FormatCitation to render it.
+type Citation struct {
+	Kurztitel            string  `json:"kurztitel"`
+	Langtitel            string  `json:"langtitel,omitempty"`
+	Kundmachungsorgan    string  `json:"kundmachungsorgan,omitempty"`
+	Paragraph            string  `json:"paragraph,omitempty"`
+	Eli                  string  `json:"eli,omitempty"`
+	Inkrafttreten        string  `json:"inkrafttreten,omitempty"`
+	Ausserkrafttreten    *string `json:"ausserkrafttreten"`
+	Geschaeftszahl       string  `json:"geschaeftszahl,omitempty"`
+	Entscheidungsdatum   string  `json:"entscheidungsdatum,omitempty"`
+	Leitsatz             string  `json:"leitsatz,omitempty"`
+	Gericht              string  `json:"gericht,omitempty"`
+	Ecli                 string  `json:"ecli,omitempty"`
+	Unterzeichnungsdatum string  `json:"unterzeichnungsdatum,omitempty"`
+	Kundmachungsnummer   string  `json:"kundmachungsnummer,omitempty"`
+	Kundmachungsdatum    string  `json:"kundmachungsdatum,omitempty"`
+}
+
+// ContentURLs holds the URLs of a document's content formats.
+type ContentURLs struct {
+	HTML string `json:"html"`
+	XML  string `json:"xml"`
+	PDF  string `json:"pdf"`
+	RTF  string `json:"rtf"`
+}
+
+// ForType returns the URL for the given data type ("html", "xml", "pdf" or
+// "rtf"), or "" if the document has no such rendition.
+func (c ContentURLs) ForType(dataType string) string {
+	return model.ContentURLs(c).ForType(dataType)
+}
+
+// ChangeType is the kind of change a HistoryEvent reports.
+type ChangeType string
+
+// Kinds of change reported by HistoryEvent.Aenderung.
+const (
+	ChangeNew     = ChangeType(model.ChangeNew)
+	ChangeUpdated = ChangeType(model.ChangeUpdated)
+	ChangeDeleted = ChangeType(model.ChangeDeleted)
+)
+
+// HistoryEvent is a change of a single document.
+type HistoryEvent struct {
+	Dokumentnummer string     `json:"dokumentnummer"`
+	Applikation    string     `json:"applikation"`
+	Organ          string     `json:"organ,omitempty"`
+	Titel          string     `json:"titel,omitempty"`
+	Geaendert      string     `json:"geaendert"` // change timestamp, e.g. "2024-06-30T10:15:00"
+	Aenderung      ChangeType `json:"aenderung"`
+	DokumentURL    string     `json:"dokument_url,omitempty"`
+}
+
+// Day returns the date part of the change timestamp (JJJJ-MM-TT).
+func (e HistoryEvent) Day() string {
+	return e.internal().Day()
+}
+
+// Time returns the time of day of the change (HH:MM), or "" if the API
+// reported only a date.
+func (e HistoryEvent) Time() string {
+	return e.internal().Time()
+}
+
+// HistoryResult is one page of change events.
+type HistoryResult struct {
+	TotalHits int            `json:"total_hits"`
+	Page      int            `json:"page"`
+	PageSize  int            `json:"page_size"`
+	HasMore   bool           `json:"has_more"`
+	Events    []HistoryEvent `json:"events"`
+}
+
+// The conversions below are the only place where the result models cross
+// the package boundary. A field added to an internal model must be added
+// here as well; TestConversions_CoverAllFields fails until it is.
+
+func newSearchResult(r model.SearchResult) SearchResult {
+	docs := make([]Document, len(r.Documents))
+	for i, d := range r.Documents {
+		docs[i] = newDocument(d)
+	}
+	if r.Documents == nil {
+		docs = nil
+	}
+	return SearchResult{
+		TotalHits: r.TotalHits,
+		Page:      r.Page,
+		PageSize:  r.PageSize,
+		HasMore:   r.HasMore,
+		Documents: docs,
+	}
+}
+
+func (r SearchResult) internal() model.SearchResult {
+	docs := make([]model.Document, len(r.Documents))
+	for i, d := range r.Documents {
+		docs[i] = d.internal()
+	}
+	if r.Documents == nil {
+		docs = nil
+	}
+	return model.SearchResult{
+		TotalHits: r.TotalHits,
+		Page:      r.Page,
+		PageSize:  r.PageSize,
+		HasMore:   r.HasMore,
+		Documents: docs,
+	}
+}
+
+func newDocument(d model.Document) Document {
+	return Document{
+		Dokumentnummer:             d.Dokumentnummer,
+		Applikation:                d.Applikation,
+		Titel:                      d.Titel,
+		Kurztitel:                  d.Kurztitel,
+		Citation:                   (*Citation)(d.Citation),
+		ContentURLs:                ContentURLs(d.ContentURLs),
+		DokumentURL:                d.DokumentURL,
+		GesamteRechtsvorschriftURL: d.GesamteRechtsvorschriftURL,
+		Geschaeftszahl:             d.Geschaeftszahl,
+		Leitsatz:                   d.Leitsatz,
+		Gesetzesnummer:             d.Gesetzesnummer,
+		Normtyp:                    d.Normtyp,
+		Indizes:                    d.Indizes,
+		Aenderungen:                d.Aenderungen,
+		Geschaeftszahlen:           d.Geschaeftszahlen,
+		Dokumenttyp:                d.Dokumenttyp,
+		Entscheidungsart:           d.Entscheidungsart,
+		Normen:                     d.Normen,
+		Schlagworte:                d.Schlagworte,
+		Rechtssatznummern:          d.Rechtssatznummern,
+		Typ:                        d.Typ,
+		Sitzungsdatum:              d.Sitzungsdatum,
+		Sitzungsnummer:             d.Sitzungsnummer,
+		Gesetzgebungsperiode:       d.Gesetzgebungsperiode,
+		Einbringer:                 d.Einbringer,
+		Bundesministerium:          d.Bundesministerium,
+		Abteilung:                  d.Abteilung,
+		Fundstelle:                 d.Fundstelle,
+		Partei:                     d.Partei,
+		Urheber:                    d.Urheber,
+		Nummer:                     d.Nummer,
+		Bundesland:                 d.Bundesland,
+		Behoerde:                   d.Behoerde,
+		Gemeinde:                   d.Gemeinde,
+		Bezirk:                     d.Bezirk,
+	}
+}
+
+func (d Document) internal() model.Document {
+	return model.Document{
+		Dokumentnummer:             d.Dokumentnummer,
+		Applikation:                d.Applikation,
+		Titel:                      d.Titel,
+		Kurztitel:                  d.Kurztitel,
+		Citation:                   (*model.Citation)(d.Citation),
+		ContentURLs:                model.ContentURLs(d.ContentURLs),
+		DokumentURL:                d.DokumentURL,
+		GesamteRechtsvorschriftURL: d.GesamteRechtsvorschriftURL,
+		Geschaeftszahl:             d.Geschaeftszahl,
+		Leitsatz:                   d.Leitsatz,
+		Gesetzesnummer:             d.Gesetzesnummer,
+		Normtyp:                    d.Normtyp,
+		Indizes:                    d.Indizes,
+		Aenderungen:                d.Aenderungen,
+		Geschaeftszahlen:           d.Geschaeftszahlen,
+		Dokumenttyp:                d.Dokumenttyp,
+		Entscheidungsart:           d.Entscheidungsart,
+		Normen:                     d.Normen,
+		Schlagworte:                d.Schlagworte,
+		Rechtssatznummern:          d.Rechtssatznummern,
+		Typ:                        d.Typ,
+		Sitzungsdatum:              d.Sitzungsdatum,
+		Sitzungsnummer:             d.Sitzungsnummer,
+		Gesetzgebungsperiode:       d.Gesetzgebungsperiode,
+		Einbringer:                 d.Einbringer,
+		Bundesministerium:          d.Bundesministerium,
+		Abteilung:                  d.Abteilung,
+		Fundstelle:                 d.Fundstelle,
+		Partei:                     d.Partei,
+		Urheber:                    d.Urheber,
+		Nummer:                     d.Nummer,
+		Bundesland:                 d.Bundesland,
+		Behoerde:                   d.Behoerde,
+		Gemeinde:                   d.Gemeinde,
+		Bezirk:                     d.Bezirk,
+	}
+}
+
+func newHistoryResult(r model.HistoryResult) HistoryResult {
+	events := make([]HistoryEvent, len(r.Events))
+	for i, e := range r.Events {
+		events[i] = newHistoryEvent(e)
+	}
+	if r.Events == nil {
+		events = nil
+	}
+	return HistoryResult{
+		TotalHits: r.TotalHits,
+		Page:      r.Page,
+		PageSize:  r.PageSize,
+		HasMore:   r.HasMore,
+		Events:    events,
+	}
+}
+
+func newHistoryEvent(e model.HistoryEvent) HistoryEvent {
+	return HistoryEvent{
+		Dokumentnummer: e.Dokumentnummer,
+		Applikation:    e.Applikation,
+		Organ:          e.Organ,
+		Titel:          e.Titel,
+		Geaendert:      e.Geaendert,
+		Aenderung:      ChangeType(e.Aenderung),
+		DokumentURL:    e.DokumentURL,
+	}
+}
+
+func (e HistoryEvent) internal() model.HistoryEvent {
+	return model.HistoryEvent{
+		Dokumentnummer: e.Dokumentnummer,
+		Applikation:    e.Applikation,
+		Organ:          e.Organ,
+		Titel:          e.Titel,
+		Geaendert:      e.Geaendert,
+		Aenderung:      model.ChangeType(e.Aenderung),
+		DokumentURL:    e.DokumentURL,
+	}
+}
@@ -0,0 +1,602 @@
+package ris
+
+import (
+	"net/url"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/query"
+)
+
+// Endpoints of the RIS API, as returned by Query.Endpoint.
+const (
+	EndpointBundesrecht = api.EndpointBundesrecht
+	EndpointLandesrecht = api.EndpointLandesrecht
+	EndpointJudikatur   = api.EndpointJudikatur
+	EndpointBezirke     = api.EndpointBezirke
+	EndpointGemeinden   = api.EndpointGemeinden
+	EndpointSonstige    = api.EndpointSonstige
+	EndpointHistory     = api.EndpointHistory
+)
+
+// Query is a search against one RIS endpoint. It is implemented by the
+// query types of this package (BundesrechtQuery, JudikaturQuery, …).
+//
+// Enumerated fields of the queries take the lower-case keys of the CLI
+// flags (e.g. App "brkons", Court "vfgh", State "wien"); dates are
+// JJJJ-MM-TT.
+type Query interface {
+	// Endpoint returns the API endpoint the query is sent to, e.g.
+	// EndpointBundesrecht.
+	Endpoint() string
+	// Params validates the query and returns its API parameters. It returns
+	// a *QueryError for missing or invalid fields.
+	Params() (url.Values, error)
+
+	// internal returns the query the client sends.
+	internal() query.Query
+}
+
+// queryParams implements Query.Params for q.
+func queryParams(q Query) (url.Values, error) {
+	params, err := q.internal().Params()
+	if err != nil {
+		return nil, publicError(err)
+	}
+	return params.Values(), nil
+}
+
+// Paging selects a result page. The zero value requests the first page with
+// the API's default page size.
+type Paging struct {
+	// Page is the 1-based page number; 0 leaves it to the API.
+	Page int
+	// Size is the number of documents per page: 10, 20, 50 or 100; 0 leaves
+	// it to the API.
+	Size int
+}
+
+// BundesrechtQuery searches federal law (Bundesrecht).
+type BundesrechtQuery struct {
+	// App is the application: brkons (default), begut, bgblauth or erv.
+	App string
+	// Search is a full-text search.
+	Search string
+	// Title searches the title of the law.
+	Title string
+	// Paragraph restricts the search to a paragraph number, e.g. "1295".
+	Paragraph string
+	// Date selects the version in force on that date (JJJJ-MM-TT).
+	Date string
+	// LawNumber is the Gesetzesnummer, e.g. "10001622" for the ABGB.
+	LawNumber string
+	// Type is the norm type, e.g. BG, BVG or V.
+	Type string
+	// Index searches the subject index (Sachgebiet), e.g. "20/01".
+	Index string
+	// Keywords searches the keywords (Schlagworte).
+	Keywords string
+}
+
+// Endpoint implements Query.
+func (q BundesrechtQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query. At least one of Search, Title, Paragraph,
+// LawNumber, Index or Keywords is required. Type and Index are only
+// supported by the consolidated law (brkons).
+func (q BundesrechtQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q BundesrechtQuery) internal() query.Query { return query.BundesrechtQuery(q) }
+
+// BgblQuery searches the federal law gazette (Bundesgesetzblatt).
+type BgblQuery struct {
+	// App is the application: bgblauth (default), bgblpdf or bgblalt.
+	App string
+	// Number is the gazette number.
+	Number string
+	// Year is the volume (Jahrgang).
+	Year string
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// Part is the gazette part: 1 (laws), 2 (ordinances) or 3 (treaties).
+	Part string
+}
+
+// Endpoint implements Query.
+func (q BgblQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query. At least one of Number, Year, Search or Title is
+// required.
+func (q BgblQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q BgblQuery) internal() query.Query { return query.BgblQuery(q) }
+
+// RegvorlQuery searches government bills (Regierungsvorlagen).
+type RegvorlQuery struct {
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// From and To bound the resolution date (JJJJ-MM-TT).
+	From, To string
+	// Ministry is the submitting ministry, e.g. bmf or bmj.
+	Ministry string
+	// Since restricts to documents added to RIS within a period, e.g.
+	// einemmonat.
+	Since string
+	// SortDir is the sort direction: asc or desc.
+	SortDir string
+	// SortBy is the sort column: kurztitel, stelle or datum.
+	SortBy string
+}
+
+// Endpoint implements Query.
+func (q RegvorlQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query. At least one of Search, Title, From, Ministry or
+// Since is required.
+func (q RegvorlQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q RegvorlQuery) internal() query.Query { return query.RegvorlQuery(q) }
+
+// LandesrechtQuery searches consolidated state law (Landesrecht).
+type LandesrechtQuery struct {
+	// Search is a full-text search.
+	Search string
+	// Title searches the title of the law.
+	Title string
+	// State restricts the search to one state, e.g. wien or tirol.
+	State string
+}
+
+// Endpoint implements Query.
+func (q LandesrechtQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query. At least one of Search, Title or State is
+// required.
+func (q LandesrechtQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q LandesrechtQuery) internal() query.Query { return query.LandesrechtQuery(q) }
+
+// LgblQuery searches the state law gazettes (Landesgesetzblätter).
+type LgblQuery struct {
+	// App is the application: lgblauth (default), lgbl or lgblno.
+	App string
+	// Number is the gazette number.
+	Number string
+	// Year is the volume (Jahrgang).
+	Year string
+	// State restricts the search to one state, e.g. wien or tirol.
+	State string
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+}
+
+// Endpoint implements Query.
+func (q LgblQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query. At least one of Number, Year, State, Search or
+// Title is required.
+func (q LgblQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q LgblQuery) internal() query.Query { return query.LgblQuery(q) }
+
+// VerordnungenQuery searches the state ordinance gazettes (Verordnungsblätter).
+type VerordnungenQuery struct {
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// State restricts the search to one state, e.g. wien or tirol.
+	State string
+	// Number is the announcement number.
+	Number string
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+}
+
+// Endpoint implements Query.
+func (q VerordnungenQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query. At least one of Search, Title, State, Number or
+// From is required.
+func (q VerordnungenQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q VerordnungenQuery) internal() query.Query { return query.VerordnungenQuery(q) }
+
+// JudikaturQuery searches court decisions (Judikatur).
+type JudikaturQuery struct {
+	// Court is the court or collection: justiz (default), vfgh, vwgh, bvwg,
+	// lvwg, dsk, asylgh, normenliste, pvak, gbk or dok.
+	Court string
+	// Search is a full-text search.
+	Search string
+	// Norm searches cited norms, e.g. "1319a ABGB".
+	Norm string
+	// CaseNumber is the case number (Geschäftszahl), e.g. "5Ob234/20b".
+	CaseNumber string
+	// From and To bound the decision date (JJJJ-MM-TT).
+	From, To string
+}
+
+// Endpoint implements Query.
+func (q JudikaturQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query. At least one of Search, Norm or CaseNumber is
+// required.
+func (q JudikaturQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q JudikaturQuery) internal() query.Query { return query.JudikaturQuery(q) }
+
+// HistoryQuery searches the change history of an application.
+type HistoryQuery struct {
+	// App is the application, e.g. bundesnormen or justiz. Required.
+	App string
+	// From and To bound the change date (JJJJ-MM-TT).
+	From, To string
+	// IncludeDeleted includes deleted documents.
+	IncludeDeleted bool
+}
+
+// Endpoint implements Query.
+func (q HistoryQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query. App and at least one of From or To are required.
+func (q HistoryQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q HistoryQuery) internal() query.Query { return query.HistoryQuery(q) }
+
+// BezirkeQuery searches announcements of the district administrative
+// authorities (Bezirksverwaltungsbehörden).
+type BezirkeQuery struct {
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// State restricts the search to one state, e.g. tirol.
+	State string
+	// Authority is the district authority, e.g.
+	// "Bezirkshauptmannschaft Innsbruck".
+	Authority string
+	// Number is the announcement number.
+	Number string
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// Since restricts to documents added to RIS within a period, e.g.
+	// einemmonat.
+	Since string
+}
+
+// Endpoint implements Query.
+func (q BezirkeQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query. At least one of Search, Title, State, Authority
+// or Number is required.
+func (q BezirkeQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q BezirkeQuery) internal() query.Query { return query.BezirkeQuery(q) }
+
+// GemeindenQuery searches municipal law. Some fields apply to only one of
+// the two applications: Gr (consolidated municipal law) and GrA
+// (municipal gazettes).
+type GemeindenQuery struct {
+	// App is the application: gr (default) or gra.
+	App string
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// State is the state name as used by the API, e.g. "Tirol".
+	State string
+	// Municipality is the municipality name, e.g. "Graz".
+	Municipality string
+	// FileNumber is the file number (Gr only).
+	FileNumber string
+	// Index is the subject index (Gr only), e.g. gesundheit.
+	Index string
+	// District is the district (GrA only).
+	District string
+	// Gemeindeverband is the association of municipalities (GrA only).
+	Gemeindeverband string
+	// AnnouncementNr is the announcement number (GrA only).
+	AnnouncementNr string
+	// Date selects the version in force on that date (Gr only, JJJJ-MM-TT).
+	Date string
+	// From and To bound the announcement date (GrA only, JJJJ-MM-TT).
+	From, To string
+	// Since restricts to documents added to RIS within a period, e.g.
+	// einemmonat.
+	Since string
+	// SortDir is the sort direction: asc or desc.
+	SortDir string
+	// SortBy is the sort column (Gr only): geschaeftszahl, bundesland or
+	// gemeinde.
+	SortBy string
+}
+
+// Endpoint implements Query.
+func (q GemeindenQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query. At least one search field is required.
+func (q GemeindenQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q GemeindenQuery) internal() query.Query { return query.GemeindenQuery(q) }
+
+// DocumentQuery looks up a single document by its document number, e.g. to
+// find its content URLs when they cannot be derived from the number.
+type DocumentQuery struct {
+	// Number is the document number, e.g. "NOR40000001". Required.
+	Number string
+}
+
+// Endpoint implements Query. The endpoint is derived from the number's
+// prefix.
+func (q DocumentQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query.
+func (q DocumentQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q DocumentQuery) internal() query.Query { return query.DocumentQuery(q) }
+
+// Sonstige holds the fields shared by the queries of the Sonstige
+// applications (MrpQuery, ErlaesseQuery, …). None of them is required.
+type Sonstige struct {
+	// Search is a full-text search.
+	Search string
+	// Title searches the title.
+	Title string
+	// Since restricts to documents added to RIS within a period, e.g.
+	// einemmonat.
+	Since string
+	// SortDir is the sort direction: asc or desc.
+	SortDir string
+}
+
+// MrpQuery searches the minutes of the Council of Ministers
+// (Ministerratsprotokolle).
+type MrpQuery struct {
+	Sonstige
+	// From and To bound the session date (JJJJ-MM-TT).
+	From, To string
+	// Submitter is the submitting ministry.
+	Submitter string
+	// Session is the session number.
+	Session string
+	// Period is the legislative period.
+	Period string
+	// FileNumber is the file number (Geschäftszahl).
+	FileNumber string
+}
+
+// Endpoint implements Query.
+func (q MrpQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query.
+func (q MrpQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q MrpQuery) internal() query.Query {
+	return query.MrpQuery{
+		Sonstige:   query.Sonstige(q.Sonstige),
+		From:       q.From,
+		To:         q.To,
+		Submitter:  q.Submitter,
+		Session:    q.Session,
+		Period:     q.Period,
+		FileNumber: q.FileNumber,
+	}
+}
+
+// ErlaesseQuery searches ministerial decrees (Erlässe).
+type ErlaesseQuery struct {
+	Sonstige
+	// From and To bound the date of entry into force (JJJJ-MM-TT).
+	From, To string
+	// Ministry is the issuing ministry, e.g. bmf.
+	Ministry string
+	// Department is the department (Abteilung).
+	Department string
+	// Source is the publication reference (Fundstelle).
+	Source string
+	// Norm searches cited norms.
+	Norm string
+	// Date selects the version in force on that date (JJJJ-MM-TT).
+	Date string
+}
+
+// Endpoint implements Query.
+func (q ErlaesseQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query.
+func (q ErlaesseQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q ErlaesseQuery) internal() query.Query {
+	return query.ErlaesseQuery{
+		Sonstige:   query.Sonstige(q.Sonstige),
+		From:       q.From,
+		To:         q.To,
+		Ministry:   q.Ministry,
+		Department: q.Department,
+		Source:     q.Source,
+		Norm:       q.Norm,
+		Date:       q.Date,
+	}
+}
+
+// UptsQuery searches party transparency decisions (UPTS).
+type UptsQuery struct {
+	Sonstige
+	// From and To bound the decision date (JJJJ-MM-TT).
+	From, To string
+	// Party is the political party: spoe, oevp, fpoe, gruene, neos or bzoe.
+	Party string
+	// FileNumber is the file number (Geschäftszahl).
+	FileNumber string
+	// Norm searches cited norms.
+	Norm string
+}
+
+// Endpoint implements Query.
+func (q UptsQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query.
+func (q UptsQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q UptsQuery) internal() query.Query {
+	return query.UptsQuery{
+		Sonstige:   query.Sonstige(q.Sonstige),
+		From:       q.From,
+		To:         q.To,
+		Party:      q.Party,
+		FileNumber: q.FileNumber,
+		Norm:       q.Norm,
+	}
+}
+
+// KmgerQuery searches court announcements (KmGer).
+type KmgerQuery struct {
+	Sonstige
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// Type is the announcement type: geschaeftsordnung or
+	// geschaeftsverteilung.
+	Type string
+	// CourtName is the court.
+	CourtName string
+	// FileNumber is the file number (Geschäftszahl).
+	FileNumber string
+}
+
+// Endpoint implements Query.
+func (q KmgerQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query.
+func (q KmgerQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q KmgerQuery) internal() query.Query {
+	return query.KmgerQuery{
+		Sonstige:   query.Sonstige(q.Sonstige),
+		From:       q.From,
+		To:         q.To,
+		Type:       q.Type,
+		CourtName:  q.CourtName,
+		FileNumber: q.FileNumber,
+	}
+}
+
+// AvsvQuery searches social insurance announcements (AVSV).
+type AvsvQuery struct {
+	Sonstige
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// DocType is the document type (Dokumentart).
+	DocType string
+	// Author is the issuing institution: dvsv, pva, oegk, auva, svs or
+	// bvaeb.
+	Author string
+	// AvsvNumber is the AVSV number.
+	AvsvNumber string
+}
+
+// Endpoint implements Query.
+func (q AvsvQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query.
+func (q AvsvQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q AvsvQuery) internal() query.Query {
+	return query.AvsvQuery{
+		Sonstige:   query.Sonstige(q.Sonstige),
+		From:       q.From,
+		To:         q.To,
+		DocType:    q.DocType,
+		Author:     q.Author,
+		AvsvNumber: q.AvsvNumber,
+	}
+}
+
+// AvnQuery searches veterinary announcements (AVN).
+type AvnQuery struct {
+	Sonstige
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// AvnNumber is the AVN number.
+	AvnNumber string
+	// Type is the announcement type: kundmachung, verordnung or erlass.
+	Type string
+}
+
+// Endpoint implements Query.
+func (q AvnQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query.
+func (q AvnQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q AvnQuery) internal() query.Query {
+	return query.AvnQuery{
+		Sonstige:  query.Sonstige(q.Sonstige),
+		From:      q.From,
+		To:        q.To,
+		AvnNumber: q.AvnNumber,
+		Type:      q.Type,
+	}
+}
+
+// SpgQuery searches health structure plans (SPG).
+type SpgQuery struct {
+	Sonstige
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// SpgNumber is the SPG number.
+	SpgNumber string
+	// OsgType is the ÖSG type: oesg or oesg-grossgeraete.
+	OsgType string
+	// RsgType is the RSG type: rsg or rsg-grossgeraete.
+	RsgType string
+	// RsgState is the state of a regional plan (RSG).
+	RsgState string
+}
+
+// Endpoint implements Query.
+func (q SpgQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query.
+func (q SpgQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q SpgQuery) internal() query.Query {
+	return query.SpgQuery{
+		Sonstige:  query.Sonstige(q.Sonstige),
+		From:      q.From,
+		To:        q.To,
+		SpgNumber: q.SpgNumber,
+		OsgType:   q.OsgType,
+		RsgType:   q.RsgType,
+		RsgState:  q.RsgState,
+	}
+}
+
+// PruefGewOQuery searches trade examinations (PrüfGewO).
+type PruefGewOQuery struct {
+	Sonstige
+	// From and To bound the announcement date (JJJJ-MM-TT).
+	From, To string
+	// Type is the examination type: befaehigung, eignung or meister.
+	Type string
+}
+
+// Endpoint implements Query.
+func (q PruefGewOQuery) Endpoint() string { return q.internal().Endpoint() }
+
+// Params implements Query.
+func (q PruefGewOQuery) Params() (url.Values, error) { return queryParams(q) }
+
+func (q PruefGewOQuery) internal() query.Query {
+	return query.PruefGewOQuery{
+		Sonstige: query.Sonstige(q.Sonstige),
+		From:     q.From,
+		To:       q.To,
+		Type:     q.Type,
+	}
+}
@@ -0,0 +1,299 @@
+// Package ris is the Go SDK for the RIS OGD API (Rechtsinformationssystem
+// des Bundes), the library behind the risgo CLI.
+//
+// A Client sends typed queries (BundesrechtQuery, JudikaturQuery, …) and
+// returns parsed results; documents are retrieved by their document number
+// using the same prefix routing as "risgo dokument". The Write* functions
+// render results like the CLI does.
+//
+//	client, err := ris.NewClient(ris.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	result, err := client.Search(ctx, ris.BundesrechtQuery{Title: "ABGB", Paragraph: "1295"}, ris.Paging{})
+//
+// # Compatibility
+//
+// Package ris follows the semantic versioning of the risgo module: within a
+// major version, exported identifiers are not removed or changed
+// incompatibly. All queries, results and errors are declared in this
+// package and converted at its boundary, so changes to the internal packages
+// do not reach callers. Result structs may gain fields and query structs may
+// gain optional fields; construct them with field names. The JSON encoding
+// of the result models is the one of "risgo --json" and changes only in the
+// same compatible way. Everything outside pkg/ is internal and carries no
+// guarantees.
+package ris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"time"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/cache"
+	"github.com/philrox/risgo/internal/model"
+	"github.com/philrox/risgo/internal/parser"
+	"github.com/philrox/risgo/internal/query"
+)
+
+// DefaultBaseURL is the base URL of the public RIS OGD API v2.6.
+const DefaultBaseURL = api.DefaultBaseURL
+
+// ErrNotFound is returned when no document has the requested number.
+var ErrNotFound = errors.New("Dokument nicht gefunden")
+
+// ErrNoContent is returned by OpenDocument for documents that are published
+// as metadata only.
+var ErrNoContent = errors.New("Dokument hat keinen abrufbaren Inhalt")
+
+// Options configures a Client. The zero value talks to the public RIS API
+// without caching, with a 30s timeout and no retries.
+type Options struct {
+	// BaseURL overrides the API base URL, e.g. for a mirror or a
+	// ristest server. Defaults to DefaultBaseURL.
+	BaseURL string
+	// Timeout bounds each HTTP request (default 30s).
+	Timeout time.Duration
+	// Retries is the number of retries after a transient failure
+	// (429, 5xx, timeouts).
+	Retries int
+
+	// RateLimit is the request budget in requests per second (0 disables
+	// limiting). RateBurst is the number of requests that may be sent
+	// back-to-back.
+	RateLimit float64
+	RateBurst int
+
+	// CacheDir enables the on-disk response cache in this directory. The
+	// cache may be shared with the risgo CLI. It is not size-limited and
+	// never evicts entries; remove the directory to reclaim space.
+	CacheDir string
+
+	// UserAgent is sent with every request. Please identify your service,
+	// e.g. "myservice/1.2 (ops@example.com)".
+	UserAgent string
+	// ProxyURL routes all requests through this proxy. Empty uses
+	// HTTPS_PROXY / HTTP_PROXY / NO_PROXY from the environment.
+	ProxyURL string
+	// CAFiles are PEM files with additional trusted root certificates.
+	CAFiles []string
+	// AllowedHosts extends the document host allowlist, e.g. for an
+	// internal mirror.
+	AllowedHosts []string
+	// MaxResponseSize limits a single response body in bytes (64 MiB if
+	// zero, negative disables the limit).
+	MaxResponseSize int64
+}
+
+// Client is a RIS API client. It is safe for concurrent use.
+type Client struct {
+	api *api.Client
+}
+
+// NewClient creates a client. It fails if the proxy, TLS or host settings
+// are invalid.
+func NewClient(opts Options) (*Client, error) {
+	var respCache *cache.Cache
+	if opts.CacheDir != "" {
+		respCache = cache.New(opts.CacheDir)
+	}
+	c, err := api.NewClient(api.ClientOptions{
+		BaseURL:         opts.BaseURL,
+		Timeout:         opts.Timeout,
+		Retries:         opts.Retries,
+		RateLimit:       opts.RateLimit,
+		RateBurst:       opts.RateBurst,
+		Cache:           respCache,
+		UserAgent:       opts.UserAgent,
+		ProxyURL:        opts.ProxyURL,
+		CAFiles:         opts.CAFiles,
+		AllowedHosts:    opts.AllowedHosts,
+		MaxResponseSize: opts.MaxResponseSize,
+	})
+	if err != nil {
+		return nil, publicError(err)
+	}
+	return &Client{api: c}, nil
+}
+
+// Search returns one result page of q.
+func (c *Client) Search(ctx context.Context, q Query, paging Paging) (SearchResult, error) {
+	params, err := searchParams(q, paging)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	body, err := c.api.OpenSearch(ctx, q.Endpoint(), params)
+	if err != nil {
+		return SearchResult{}, publicError(err)
+	}
+	defer body.Close()
+	result, err := parser.DecodeSearchResponse(body)
+	if err != nil {
+		return SearchResult{}, publicError(err)
+	}
+	return newSearchResult(result), nil
+}
+
+// SearchPages returns an iterator over consecutive result pages of q,
+// starting at paging.Page (default 1). Pages are requested as the caller
+// consumes them; iteration ends after the last page or the first error.
+func (c *Client) SearchPages(ctx context.Context, q Query, paging Paging) iter.Seq2[SearchResult, error] {
+	params, err := searchParams(q, paging)
+	if err != nil {
+		return func(yield func(SearchResult, error) bool) { yield(SearchResult{}, err) }
+	}
+	return pages(c.api.SearchPages(ctx, q.Endpoint(), params), newSearchResult)
+}
+
+// SearchAll returns an iterator over the documents of all result pages of
+// q, fetched lazily with paging.Size documents per page.
+func (c *Client) SearchAll(ctx context.Context, q Query, paging Paging) iter.Seq2[Document, error] {
+	return func(yield func(Document, error) bool) {
+		for result, err := range c.SearchPages(ctx, q, paging) {
+			if err != nil {
+				yield(Document{}, err)
+				return
+			}
+			for _, doc := range result.Documents {
+				if !yield(doc, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// History returns one page of the change events matched by q.
+func (c *Client) History(ctx context.Context, q HistoryQuery, paging Paging) (HistoryResult, error) {
+	params, err := searchParams(q, paging)
+	if err != nil {
+		return HistoryResult{}, err
+	}
+	body, err := c.api.OpenSearch(ctx, q.Endpoint(), params)
+	if err != nil {
+		return HistoryResult{}, publicError(err)
+	}
+	defer body.Close()
+	result, err := parser.DecodeHistoryResponse(body)
+	if err != nil {
+		return HistoryResult{}, publicError(err)
+	}
+	return newHistoryResult(result), nil
+}
+
+// HistoryPages is like SearchPages for change events: it returns an
+// iterator over consecutive pages of q, starting at paging.Page.
+func (c *Client) HistoryPages(ctx context.Context, q HistoryQuery, paging Paging) iter.Seq2[HistoryResult, error] {
+	params, err := searchParams(q, paging)
+	if err != nil {
+		return func(yield func(HistoryResult, error) bool) { yield(HistoryResult{}, err) }
+	}
+	return pages(c.api.HistoryPages(ctx, params), newHistoryResult)
+}
+
+// HistoryPagesConcurrent is like HistoryPages but, once the first page has
+// revealed the total hit count, fetches up to concurrency further pages in
+// parallel. Pages are still yielded in order. A page that fails is yielded
+// as a *PageError and the iteration continues; failures of the first page
+// and cancellation of ctx end it.
+func (c *Client) HistoryPagesConcurrent(ctx context.Context, q HistoryQuery, paging Paging, concurrency int) iter.Seq2[HistoryResult, error] {
+	params, err := searchParams(q, paging)
+	if err != nil {
+		return func(yield func(HistoryResult, error) bool) { yield(HistoryResult{}, err) }
+	}
+	return pages(c.api.HistoryPagesConcurrent(ctx, params, concurrency), newHistoryResult)
+}
+
+// pages converts the pages and errors of an internal page iterator.
+func pages[In, Out any](seq iter.Seq2[In, error], convert func(In) Out) iter.Seq2[Out, error] {
+	return func(yield func(Out, error) bool) {
+		for page, err := range seq {
+			var out Out
+			if err != nil {
+				err = publicError(err)
+			} else {
+				out = convert(page)
+			}
+			if !yield(out, err) {
+				return
+			}
+		}
+	}
+}
+
+// searchParams encodes q and paging.
+func searchParams(q Query, paging Paging) (*api.Params, error) {
+	params, err := q.internal().Params()
+	if err != nil {
+		return nil, publicError(err)
+	}
+	if err := query.Paging(paging).Apply(params); err != nil {
+		return nil, publicError(err)
+	}
+	return params, nil
+}
+
+// DirectURL returns the HTML URL of a document derived from its number's
+// prefix (e.g. NOR… → Bundesnormen), or "" if the prefix is unknown.
+func DirectURL(number string) string {
+	return model.DirectURLFromPrefix(number)
+}
+
+// LookupDocument returns the metadata of the document with the given
+// number. It returns ErrNotFound if the API knows no such document.
+func (c *Client) LookupDocument(ctx context.Context, number string) (Document, error) {
+	q := DocumentQuery{Number: number}
+	result, err := c.Search(ctx, q, Paging{})
+	if err != nil {
+		return Document{}, err
+	}
+	if len(result.Documents) == 0 {
+		return Document{}, fmt.Errorf("%w: %s", ErrNotFound, number)
+	}
+	return result.Documents[0], nil
+}
+
+// OpenDocument opens the HTML content of the document with the given
+// number; the caller must close it. Like "risgo dokument" it first tries the
+// URL derived from the number's prefix (only against the public RIS hosts)
+// and falls back to looking the document up. Use WriteHTMLText to convert
+// the content to plain text.
+func (c *Client) OpenDocument(ctx context.Context, number string) (io.ReadCloser, error) {
+	if c.api.PublicAPI() {
+		if docURL := DirectURL(number); docURL != "" {
+			rc, err := c.OpenURL(ctx, docURL)
+			if err == nil || ctx.Err() != nil {
+				return rc, err
+			}
+		}
+	}
+
+	doc, err := c.LookupDocument(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	docURL := doc.ContentURLs.HTML
+	if docURL == "" {
+		docURL = doc.DokumentURL
+	}
+	if docURL == "" {
+		return nil, fmt.Errorf("%w: %s", ErrNoContent, number)
+	}
+	return c.OpenURL(ctx, docURL)
+}
+
+// OpenURL opens a document URL, e.g. one of Document.ContentURLs; the
+// caller must close it. The URL must point to a RIS host, the configured
+// base URL's origin or one of Options.AllowedHosts; otherwise OpenURL
+// returns a *URLError without sending a request.
+func (c *Client) OpenURL(ctx context.Context, docURL string) (io.ReadCloser, error) {
+	rc, err := c.api.OpenDocument(ctx, docURL)
+	if err != nil {
+		return nil, publicError(err)
+	}
+	return body{rc}, nil
+}
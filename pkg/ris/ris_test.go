@@ -0,0 +1,232 @@
+package ris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/model"
+	"github.com/philrox/risgo/pkg/ristest"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	srv, err := ristest.NewServer(ristest.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Close)
+	client, err := NewClient(Options{BaseURL: srv.BaseURL()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestOpenDocument_NotFound(t *testing.T) {
+	client := newTestClient(t)
+	if client.api.PublicAPI() {
+		t.Fatal("prefix routing must be disabled for a custom base URL")
+	}
+
+	_, err := client.OpenDocument(context.Background(), "NOR99999999")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestNewClient_DirectRouting(t *testing.T) {
+	// The environment is the CLI's business; the library honors only Options.
+	t.Setenv("RIS_BASE_URL", "http://127.0.0.1:1/")
+
+	tests := []struct {
+		baseURL string
+		want    bool
+	}{
+		{"", true},
+		{DefaultBaseURL, true},
+		{"https://data.bka.gv.at/ris/api/v2.6", true},
+		{"HTTPS://DATA.BKA.GV.AT/ris/api/v2.6/", true},
+		{"http://data.bka.gv.at/ris/api/v2.6/", false},
+		{"https://ris-mirror.example.com/ris/api/v2.6/", false},
+	}
+	for _, tt := range tests {
+		client, err := NewClient(Options{BaseURL: tt.baseURL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := client.api.PublicAPI(); got != tt.want {
+			t.Errorf("BaseURL %q: direct routing = %v, want %v", tt.baseURL, got, tt.want)
+		}
+	}
+}
+
+func TestSearch_InvalidQuery(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.Search(context.Background(), HistoryQuery{App: "bundesnormen"}, Paging{})
+	var qErr *QueryError
+	if !errors.As(err, &qErr) {
+		t.Fatalf("expected *QueryError, got %v", err)
+	}
+
+	_, err = client.Search(context.Background(), BundesrechtQuery{Search: "x"}, Paging{Size: 30})
+	if !errors.As(err, &qErr) || qErr.Fields[0] != "Size" {
+		t.Fatalf("expected *QueryError for Size, got %v", err)
+	}
+}
+
+func TestHistoryPagesConcurrent(t *testing.T) {
+	client := newTestClient(t)
+	q := HistoryQuery{App: "bundesnormen", From: "2024-01-01"}
+
+	first, err := client.History(context.Background(), q, Paging{Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []HistoryEvent
+	for page, err := range client.HistoryPagesConcurrent(context.Background(), q, Paging{Size: 10}, 4) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, page.Events...)
+	}
+	if len(events) == 0 || len(events) != first.TotalHits {
+		t.Errorf("got %d events, want all %d", len(events), first.TotalHits)
+	}
+
+	for _, err := range client.HistoryPagesConcurrent(context.Background(), HistoryQuery{App: "bundesnormen"}, Paging{}, 4) {
+		var qErr *QueryError
+		if !errors.As(err, &qErr) {
+			t.Fatalf("expected *QueryError, got %v", err)
+		}
+	}
+}
+
+// TestConversions_CoverAllFields guards the hand-written conversions
+// between the types of this package and the internal ones: every field of
+// the internal models must survive a round trip, and every field of the
+// queries embedding Sonstige must reach the internal query.
+func TestConversions_CoverAllFields(t *testing.T) {
+	var doc model.Document
+	fill(reflect.ValueOf(&doc).Elem())
+	if got := newDocument(doc).internal(); !reflect.DeepEqual(got, doc) {
+		t.Errorf("Document round trip:\n got %+v\nwant %+v", got, doc)
+	}
+	sameFields(t, reflect.TypeFor[Document](), reflect.TypeFor[model.Document]())
+
+	var event model.HistoryEvent
+	fill(reflect.ValueOf(&event).Elem())
+	if got := newHistoryEvent(event).internal(); !reflect.DeepEqual(got, event) {
+		t.Errorf("HistoryEvent round trip:\n got %+v\nwant %+v", got, event)
+	}
+	sameFields(t, reflect.TypeFor[HistoryEvent](), reflect.TypeFor[model.HistoryEvent]())
+
+	var result model.SearchResult
+	fill(reflect.ValueOf(&result).Elem())
+	if got := newSearchResult(result).internal(); !reflect.DeepEqual(got, result) {
+		t.Errorf("SearchResult round trip:\n got %+v\nwant %+v", got, result)
+	}
+	sameFields(t, reflect.TypeFor[HistoryResult](), reflect.TypeFor[model.HistoryResult]())
+
+	for _, q := range []Query{
+		&MrpQuery{}, &ErlaesseQuery{}, &UptsQuery{}, &KmgerQuery{},
+		&AvsvQuery{}, &AvnQuery{}, &SpgQuery{}, &PruefGewOQuery{},
+	} {
+		v := reflect.ValueOf(q).Elem()
+		fill(v)
+		iq := reflect.ValueOf(v.Interface().(Query).internal())
+		sameFields(t, v.Type(), iq.Type())
+		if iq.IsZero() || !reflect.DeepEqual(flatten(v), flatten(iq)) {
+			t.Errorf("%s: internal query %+v does not carry all fields of %+v", v.Type().Name(), iq, v)
+		}
+	}
+}
+
+// fill sets every field reachable from v to a non-zero value.
+func fill(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem())
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fill(v.Index(0))
+	case reflect.Struct:
+		for i := range v.NumField() {
+			fill(v.Field(i))
+		}
+	}
+}
+
+// flatten returns the string fields of the struct v, including those of
+// embedded structs, in declaration order.
+func flatten(v reflect.Value) []string {
+	var values []string
+	for i := range v.NumField() {
+		if f := v.Field(i); f.Kind() == reflect.Struct {
+			values = append(values, flatten(f)...)
+		} else {
+			values = append(values, v.Type().Field(i).Name+"="+f.String())
+		}
+	}
+	return values
+}
+
+// sameFields reports fields that are declared in only one of two struct
+// types or differ in their JSON tag.
+func sameFields(t *testing.T, pub, internal reflect.Type) {
+	t.Helper()
+	if pub.NumField() != internal.NumField() {
+		t.Errorf("%s has %d fields, %s has %d", pub, pub.NumField(), internal, internal.NumField())
+		return
+	}
+	for i := range pub.NumField() {
+		pf, inf := pub.Field(i), internal.Field(i)
+		if pf.Name != inf.Name || pf.Tag != inf.Tag {
+			t.Errorf("%s field %d is %s `%s`, %s has %s `%s`", pub, i, pf.Name, pf.Tag, internal, inf.Name, inf.Tag)
+		}
+	}
+}
+
+func TestPublicError(t *testing.T) {
+	httpErr := &api.HTTPError{StatusCode: 503, Status: "Service Unavailable", URL: "https://example.com/"}
+	err := publicError(fmt.Errorf("Suche: %w", httpErr))
+	var pubHTTP *HTTPError
+	if !errors.As(err, &pubHTTP) || pubHTTP.StatusCode != 503 || !pubHTTP.Retryable() {
+		t.Fatalf("expected *HTTPError 503, got %#v", err)
+	}
+	if want := "Suche: " + httpErr.Error(); err.Error() != want {
+		t.Errorf("message = %q, want %q", err.Error(), want)
+	}
+
+	err = publicError(&api.PageError{Page: 3, Err: &api.ResponseTooLargeError{URL: "u", Limit: 1024}})
+	var pageErr *PageError
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &pageErr) || pageErr.Page != 3 || !errors.As(err, &tooLarge) {
+		t.Fatalf("expected *PageError wrapping *ResponseTooLargeError, got %#v", err)
+	}
+
+	if err := publicError(ErrNotFound); err != ErrNotFound {
+		t.Errorf("publicError changed a public error: %v", err)
+	}
+}
+
+func TestOpenURL_DisallowedHost(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.OpenURL(context.Background(), "https://example.com/NOR12017681.html")
+	var urlErr *URLError
+	if !errors.As(err, &urlErr) || urlErr.URL != "https://example.com/NOR12017681.html" {
+		t.Fatalf("expected *URLError, got %#v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package ristest
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/philrox/risgo/internal/api"
+)
+
+// BasePlaceholder is replaced with the server's own origin (e.g.
+// "http://127.0.0.1:8080/") in served search results, so that document URLs
+// in fixtures point back to the fake server.
+const BasePlaceholder = "{{base}}"
+
+// Endpoints lists the search endpoints served by the fake API.
+var Endpoints = []string{
+	api.EndpointBundesrecht,
+	api.EndpointLandesrecht,
+	api.EndpointJudikatur,
+	api.EndpointBezirke,
+	api.EndpointGemeinden,
+	api.EndpointSonstige,
+	api.EndpointHistory,
+}
+
+//go:embed fixtures
+var builtin embed.FS
+
+// BuiltinFixtures returns the fixture tree shipped with the package.
+func BuiltinFixtures() fs.FS {
+	sub, err := fs.Sub(builtin, "fixtures")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// Fixtures holds the loaded fixture data.
+type Fixtures struct {
+	// Collections maps endpoint names to their documents.
+	Collections map[string][]Doc
+	// Documents maps document numbers to HTML content.
+	Documents map[string][]byte
+}
+
+// Doc is one OgdDocumentReference as stored in a fixture file.
+type Doc struct {
+	ID          string
+	Applikation string
+	Deleted     bool // Allgemein.Geloescht is set
+	Raw         json.RawMessage
+}
+
+// matches reports whether the document satisfies the filters the fake server
+// understands: Dokumentnummer, Applikation (Anwendung for History) and
+// IncludeDeletedDocuments, without which deleted documents are left out.
+// All other search parameters are ignored.
+func (d Doc) matches(q url.Values) bool {
+	if nr := q.Get("Dokumentnummer"); nr != "" && !strings.EqualFold(nr, d.ID) {
+		return false
+	}
+	if d.Deleted && !strings.EqualFold(q.Get("IncludeDeletedDocuments"), "true") {
+		return false
+	}
+	app := q.Get("Applikation")
+	if app == "" {
+		app = q.Get("Anwendung")
+	}
+	return app == "" || strings.EqualFold(app, d.Applikation)
+}
+
+// LoadFixtures reads a fixture tree:
+//
+//	<Endpoint>.json           JSON array of OgdDocumentReference objects
+//	documents/<ID>.html       document content served below /Dokumente/
+//
+// Missing endpoint files yield empty collections. URLs in the JSON files may
+// use BasePlaceholder to refer to the server itself.
+func LoadFixtures(fsys fs.FS) (*Fixtures, error) {
+	f := &Fixtures{
+		Collections: make(map[string][]Doc),
+		Documents:   make(map[string][]byte),
+	}
+
+	for _, endpoint := range Endpoints {
+		data, err := fs.ReadFile(fsys, endpoint+".json")
+		if errors.Is(err, fs.ErrNotExist) {
+			f.Collections[endpoint] = nil
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs, err := parseCollection(data)
+		if err != nil {
+			return nil, fmt.Errorf("Fixture %s.json ungültig: %w", endpoint, err)
+		}
+		f.Collections[endpoint] = docs
+	}
+
+	entries, err := fs.ReadDir(fsys, "documents")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".html" {
+			continue
+		}
+		body, err := fs.ReadFile(fsys, path.Join("documents", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		f.Documents[strings.TrimSuffix(e.Name(), ".html")] = body
+	}
+
+	return f, nil
+}
+
+func parseCollection(data []byte) ([]Doc, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, err
+	}
+	docs := make([]Doc, 0, len(raws))
+	for i, raw := range raws {
+		var ref struct {
+			Data struct {
+				Metadaten struct {
+					Technisch struct {
+						ID          string `json:"ID"`
+						Applikation string `json:"Applikation"`
+					} `json:"Technisch"`
+					Allgemein struct {
+						Geloescht any `json:"Geloescht"`
+					} `json:"Allgemein"`
+				} `json:"Metadaten"`
+			} `json:"Data"`
+		}
+		if err := json.Unmarshal(raw, &ref); err != nil {
+			return nil, fmt.Errorf("Eintrag %d: %w", i, err)
+		}
+		tech := ref.Data.Metadaten.Technisch
+		deleted := strings.ToLower(fmt.Sprint(ref.Data.Metadaten.Allgemein.Geloescht))
+		docs = append(docs, Doc{
+			ID:          tech.ID,
+			Applikation: tech.Applikation,
+			Deleted:     deleted == "true" || deleted == "ja" || deleted == "1",
+			Raw:         raw,
+		})
+	}
+	return docs, nil
+}
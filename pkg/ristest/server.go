@@ -0,0 +1,283 @@
+// Package ristest provides a fake RIS OGD API v2.6 server for local
+// development and integration tests.
+//
+// The server answers all search endpoints and document HTML from a fixture
+// tree, honors Seitennummer/DokumenteProSeite and reproduces the polymorphic
+// response shapes of the real API (single object vs. array, "#text" objects,
+// Hits as object or plain number). Latency, 429 and 5xx responses can be
+// injected to exercise retry and timeout handling.
+//
+// Typical use in a test:
+//
+//	srv, err := ristest.NewServer(ristest.Options{})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer srv.Close()
+//	client, err := api.NewClient(api.ClientOptions{BaseURL: srv.BaseURL()})
+package ristest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options configures the fake server. The zero value serves the built-in
+// fixtures without fault injection.
+type Options struct {
+	// Fixtures is the fixture tree (see LoadFixtures). Nil uses the built-in fixtures.
+	Fixtures fs.FS
+
+	// Latency delays every response.
+	Latency time.Duration
+
+	// FailFirst answers the first n requests with FailStatus.
+	FailFirst int
+	// FailRate answers this fraction (0..1) of requests with FailStatus.
+	FailRate float64
+	// FailStatus is the status for injected failures (default 503).
+	FailStatus int
+
+	// RateLimitEvery answers every n-th request with 429 Too Many Requests.
+	RateLimitEvery int
+	// RetryAfter is sent with injected 429 responses (default 1s).
+	RetryAfter time.Duration
+}
+
+// pageSizes maps the DokumenteProSeite enum values to page sizes.
+var pageSizes = map[string]int{
+	"Ten":        10,
+	"Twenty":     20,
+	"Fifty":      50,
+	"OneHundred": 100,
+}
+
+// Handler is the http.Handler implementing the fake API.
+type Handler struct {
+	opts     Options
+	fixtures *Fixtures
+
+	mu       sync.Mutex
+	requests int
+}
+
+// NewHandler loads the fixtures and returns the handler.
+func NewHandler(opts Options) (*Handler, error) {
+	fsys := opts.Fixtures
+	if fsys == nil {
+		fsys = BuiltinFixtures()
+	}
+	fixtures, err := LoadFixtures(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if opts.FailStatus == 0 {
+		opts.FailStatus = http.StatusServiceUnavailable
+	}
+	if opts.RetryAfter <= 0 {
+		opts.RetryAfter = time.Second
+	}
+	return &Handler{opts: opts, fixtures: fixtures}, nil
+}
+
+// Requests returns the number of requests received so far.
+func (h *Handler) Requests() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.requests
+}
+
+// ServeHTTP serves search endpoints ("/<Endpoint>", optionally below a path
+// prefix such as "/ris/api/v2.6/") and documents ("/Dokumente/.../<ID>.html").
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.requests++
+	n := h.requests
+	h.mu.Unlock()
+
+	if h.opts.Latency > 0 {
+		select {
+		case <-time.After(h.opts.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if n <= h.opts.FailFirst || (h.opts.FailRate > 0 && rand.Float64() < h.opts.FailRate) {
+		http.Error(w, http.StatusText(h.opts.FailStatus), h.opts.FailStatus)
+		return
+	}
+	if h.opts.RateLimitEvery > 0 && n%h.opts.RateLimitEvery == 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(h.opts.RetryAfter.Seconds()))))
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if strings.Contains(r.URL.Path, "/Dokumente/") {
+		h.serveDocument(w, r)
+		return
+	}
+	if docs, ok := h.fixtures.Collections[path.Base(r.URL.Path)]; ok {
+		h.serveSearch(w, r, docs)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// searchResponse mirrors the envelope of the real API.
+type searchResponse struct {
+	OgdSearchResult struct {
+		OgdDocumentResults documentResults `json:"OgdDocumentResults"`
+	} `json:"OgdSearchResult"`
+}
+
+type documentResults struct {
+	Hits any `json:"Hits"`
+	// Docs is a single object for exactly one hit and an array otherwise,
+	// like the real API (see parser.FlexibleArray).
+	Docs any `json:"OgdDocumentReference,omitempty"`
+}
+
+type hitsObject struct {
+	PageNumber string `json:"@pageNumber"`
+	PageSize   string `json:"@pageSize"`
+	Text       string `json:"#text"`
+}
+
+// writeAPIError answers like the RIS API does for rejected queries: with
+// HTTP 200 and an Error element instead of OgdDocumentResults.
+func writeAPIError(w http.ResponseWriter, query url.Values, message string) {
+	applikation := query.Get("Applikation")
+	if applikation == "" {
+		applikation = query.Get("Anwendung")
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]any{
+		"OgdSearchResult": map[string]any{
+			"Error": map[string]string{"Applikation": applikation, "Message": message},
+		},
+	})
+}
+
+func (h *Handler) serveSearch(w http.ResponseWriter, r *http.Request, docs []Doc) {
+	query := r.URL.Query()
+
+	page := 1
+	if v := query.Get("Seitennummer"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeAPIError(w, query, fmt.Sprintf("Der Wert %q für den Parameter Seitennummer ist ungültig.", v))
+			return
+		}
+		page = n
+	}
+	pageSize := 20
+	if v := query.Get("DokumenteProSeite"); v != "" {
+		n, ok := pageSizes[v]
+		if !ok {
+			writeAPIError(w, query, fmt.Sprintf("Der Wert %q für den Parameter DokumenteProSeite ist ungültig.", v))
+			return
+		}
+		pageSize = n
+	}
+
+	var hits []Doc
+	for _, d := range docs {
+		if d.matches(query) {
+			hits = append(hits, d)
+		}
+	}
+
+	var results documentResults
+	if len(hits) == 0 {
+		// Empty results carry Hits as a plain number.
+		results.Hits = "0"
+	} else {
+		results.Hits = hitsObject{
+			PageNumber: strconv.Itoa(page),
+			PageSize:   strconv.Itoa(pageSize),
+			Text:       strconv.Itoa(len(hits)),
+		}
+	}
+
+	start := min((page-1)*pageSize, len(hits))
+	end := min(start+pageSize, len(hits))
+	base := []byte(baseURL(r))
+	var refs []json.RawMessage
+	for _, d := range hits[start:end] {
+		refs = append(refs, bytes.ReplaceAll(d.Raw, []byte(BasePlaceholder), base))
+	}
+	switch len(refs) {
+	case 0:
+	case 1:
+		results.Docs = refs[0]
+	default:
+		results.Docs = refs
+	}
+
+	var resp searchResponse
+	resp.OgdSearchResult.OgdDocumentResults = results
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) serveDocument(w http.ResponseWriter, r *http.Request) {
+	name := path.Base(r.URL.Path)
+	if path.Ext(name) != ".html" {
+		http.NotFound(w, r)
+		return
+	}
+	body, ok := h.fixtures.Documents[strings.TrimSuffix(name, ".html")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(body)
+}
+
+// baseURL returns the origin the request was sent to, with a trailing slash.
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + "/"
+}
+
+// Server is a running fake API on a local port.
+type Server struct {
+	*httptest.Server
+	Handler *Handler
+}
+
+// NewServer starts a fake API server. Call Close when done.
+func NewServer(opts Options) (*Server, error) {
+	h, err := NewHandler(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{Server: httptest.NewServer(h), Handler: h}, nil
+}
+
+// BaseURL returns the URL to use as api.ClientOptions.BaseURL or RIS_BASE_URL.
+func (s *Server) BaseURL() string {
+	return s.URL + "/"
+}
@@ -0,0 +1,246 @@
+package ristest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/philrox/risgo/internal/api"
+	"github.com/philrox/risgo/internal/parser"
+)
+
+func startServer(t *testing.T, opts Options) *Server {
+	t.Helper()
+	srv, err := NewServer(opts)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newClient(t *testing.T, opts api.ClientOptions) *api.Client {
+	t.Helper()
+	client, err := api.NewClient(opts)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func search(t *testing.T, srv *Server, endpoint string, params *api.Params) []byte {
+	t.Helper()
+	client := newClient(t, api.ClientOptions{BaseURL: srv.BaseURL()})
+	body, err := client.Search(context.Background(), endpoint, params)
+	if err != nil {
+		t.Fatalf("Search(%s): %v", endpoint, err)
+	}
+	return body
+}
+
+func TestServer_AllEndpointsParse(t *testing.T) {
+	srv := startServer(t, Options{})
+	for _, endpoint := range Endpoints {
+		t.Run(endpoint, func(t *testing.T) {
+			result, err := parser.ParseSearchResponse(search(t, srv, endpoint, nil))
+			if err != nil {
+				t.Fatalf("ParseSearchResponse: %v", err)
+			}
+			if len(result.Documents) == 0 {
+				t.Fatal("expected documents from built-in fixtures")
+			}
+			for _, doc := range result.Documents {
+				if doc.Dokumentnummer == "" {
+					t.Error("document without Dokumentnummer")
+				}
+				if strings.Contains(doc.DokumentURL, BasePlaceholder) {
+					t.Errorf("placeholder not replaced in %q", doc.DokumentURL)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_Paging(t *testing.T) {
+	srv := startServer(t, Options{})
+
+	params := api.NewParams()
+	params.Set("DokumenteProSeite", "Ten")
+	first, err := parser.ParseSearchResponse(search(t, srv, api.EndpointBundesrecht, params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.TotalHits != 24 || first.PageSize != 10 || len(first.Documents) != 10 || !first.HasMore {
+		t.Errorf("page 1: total=%d size=%d docs=%d more=%v", first.TotalHits, first.PageSize, len(first.Documents), first.HasMore)
+	}
+
+	params.Set("Seitennummer", "3")
+	last, err := parser.ParseSearchResponse(search(t, srv, api.EndpointBundesrecht, params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Page != 3 || len(last.Documents) != 4 || last.HasMore {
+		t.Errorf("page 3: page=%d docs=%d more=%v", last.Page, len(last.Documents), last.HasMore)
+	}
+	if last.Documents[0].Dokumentnummer == first.Documents[0].Dokumentnummer {
+		t.Error("page 3 repeats documents of page 1")
+	}
+}
+
+func TestServer_SingleHitIsObject(t *testing.T) {
+	srv := startServer(t, Options{})
+	params := api.NewParams()
+	params.Set("Dokumentnummer", "NOR12017681")
+	body := search(t, srv, api.EndpointBundesrecht, params)
+
+	var raw struct {
+		OgdSearchResult struct {
+			OgdDocumentResults struct {
+				Docs json.RawMessage `json:"OgdDocumentReference"`
+			}
+		}
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(raw.OgdSearchResult.OgdDocumentResults.Docs), "{") {
+		t.Errorf("single hit should be encoded as object, got %.40s", raw.OgdSearchResult.OgdDocumentResults.Docs)
+	}
+
+	result, err := parser.ParseSearchResponse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Documents) != 1 || result.Documents[0].Titel != "§ 1" {
+		t.Errorf("unexpected result: %+v", result.Documents)
+	}
+}
+
+func TestServer_NoHits(t *testing.T) {
+	srv := startServer(t, Options{})
+	params := api.NewParams()
+	params.Set("Applikation", "Gibtsnicht")
+	result, err := parser.ParseSearchResponse(search(t, srv, api.EndpointJudikatur, params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalHits != 0 || len(result.Documents) != 0 {
+		t.Errorf("expected no hits, got %d", result.TotalHits)
+	}
+}
+
+func TestServer_DeletedDocuments(t *testing.T) {
+	srv := startServer(t, Options{})
+	count := func(includeDeleted string) int {
+		params := api.NewParams()
+		if includeDeleted != "" {
+			params.Set("IncludeDeletedDocuments", includeDeleted)
+		}
+		result, err := parser.ParseHistoryResponse(search(t, srv, api.EndpointHistory, params))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(result.Events)
+	}
+
+	without, with := count(""), count("true")
+	if with != without+1 {
+		t.Errorf("history has %d entries with deleted documents, %d without; want exactly one deleted", with, without)
+	}
+	if n := count("false"); n != without {
+		t.Errorf("IncludeDeletedDocuments=false: %d entries, want %d", n, without)
+	}
+}
+
+func TestServer_Document(t *testing.T) {
+	srv := startServer(t, Options{})
+	params := api.NewParams()
+	params.Set("Dokumentnummer", "NOR12017681")
+	result, err := parser.ParseSearchResponse(search(t, srv, api.EndpointBundesrecht, params))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := newClient(t, api.ClientOptions{BaseURL: srv.BaseURL()})
+	html, err := client.FetchDocument(context.Background(), result.Documents[0].ContentURLs.HTML)
+	if err != nil {
+		t.Fatalf("FetchDocument: %v", err)
+	}
+	if !strings.Contains(html, "bürgerliche Recht") {
+		t.Errorf("unexpected document content: %.80s", html)
+	}
+}
+
+func TestServer_CustomFixtures(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Sonstige.json": {Data: []byte(`[{"Data":{"Metadaten":{"Technisch":{"ID":"X1","Applikation":"Mrp"}}}}]`)},
+	}
+	srv := startServer(t, Options{Fixtures: fsys})
+
+	result, err := parser.ParseSearchResponse(search(t, srv, api.EndpointSonstige, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Documents) != 1 || result.Documents[0].Dokumentnummer != "X1" {
+		t.Errorf("unexpected documents: %+v", result.Documents)
+	}
+	if result := search(t, srv, api.EndpointBundesrecht, nil); !strings.Contains(string(result), `"Hits":"0"`) {
+		t.Errorf("missing fixture file should yield an empty collection, got %s", result)
+	}
+}
+
+func TestServer_InvalidPageSize(t *testing.T) {
+	srv := startServer(t, Options{})
+	params := api.NewParams()
+	params.Set("Applikation", "BrKons")
+	params.Set("DokumenteProSeite", "Seven")
+
+	_, err := parser.ParseSearchResponse(search(t, srv, api.EndpointBundesrecht, params))
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *api.APIError, got %T: %v", err, err)
+	}
+	if apiErr.Applikation != "BrKons" || !strings.Contains(apiErr.Message, "DokumenteProSeite") {
+		t.Errorf("unexpected API error: %+v", apiErr)
+	}
+}
+
+func TestServer_FaultInjection(t *testing.T) {
+	srv := startServer(t, Options{FailFirst: 1, FailStatus: 502, RateLimitEvery: 3, RetryAfter: 2 * time.Second})
+
+	want := []int{502, 200, 429, 200}
+	for i, status := range want {
+		resp, err := http.Get(srv.BaseURL() + "Bundesrecht")
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Errorf("request %d: status = %d, want %d", i+1, resp.StatusCode, status)
+		}
+		if status == 429 && resp.Header.Get("Retry-After") != "2" {
+			t.Errorf("Retry-After = %q, want %q", resp.Header.Get("Retry-After"), "2")
+		}
+	}
+	if got := srv.Handler.Requests(); got != len(want) {
+		t.Errorf("Requests() = %d, want %d", got, len(want))
+	}
+}
+
+func TestServer_Latency(t *testing.T) {
+	srv := startServer(t, Options{Latency: time.Second})
+	client := newClient(t, api.ClientOptions{BaseURL: srv.BaseURL(), Timeout: 50 * time.Millisecond})
+
+	_, err := client.Search(context.Background(), api.EndpointBundesrecht, nil)
+	var te *api.TimeoutError
+	if !errors.As(err, &te) {
+		t.Errorf("expected *api.TimeoutError, got %T: %v", err, err)
+	}
+}